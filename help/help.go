@@ -54,6 +54,21 @@ type Model struct {
 	// due to width. Periods of ellipsis by default.
 	Ellipsis string
 
+	// ShortHelpMaxLines is the number of lines the short help may wrap onto
+	// when its items don't fit within Width. Items are only ever broken
+	// between bindings. If 1 or less the short help is kept to a single line.
+	ShortHelpMaxLines int
+
+	// FlowFullHelp, when true, flows the full help into columns which fit
+	// within Width if the KeyMap's FullHelp returns a single group of
+	// bindings. Multiple groups are always rendered one column per group.
+	// See FullHelpFlowView.
+	FlowFullHelp bool
+
+	// FullHelpMaxColumns is the maximum number of columns bindings are flowed
+	// into. If 0 or less there's no maximum other than Width.
+	FullHelpMaxColumns int
+
 	Styles Styles
 }
 
@@ -103,41 +118,58 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 // View renders the help view's current state.
 func (m Model) View(k KeyMap) string {
 	if m.ShowAll {
-		return m.FullHelpView(k.FullHelp())
+		groups := k.FullHelp()
+		if m.FlowFullHelp && len(groups) == 1 {
+			return m.FullHelpFlowView(groups[0])
+		}
+		return m.FullHelpView(groups)
 	}
 	return m.ShortHelpView(k.ShortHelp())
 }
 
 // ShortHelpView renders a single line help view from a slice of keybindings.
-// If the line is longer than the maximum width it will be gracefully
-// truncated, showing only as many help items as possible.
+// If the line is longer than the maximum width it will wrap onto up to
+// ShortHelpMaxLines lines, after which it will be gracefully truncated,
+// showing only as many help items as possible.
 func (m Model) ShortHelpView(bindings []key.Binding) string {
 	if len(bindings) == 0 {
 		return ""
 	}
 
-	var b strings.Builder
-	var totalWidth int
-	var separator = m.Styles.ShortSeparator.Inline(true).Render(m.ShortSeparator)
+	var (
+		lines      []string
+		b          strings.Builder
+		totalWidth int // width of the current line
+		separator  = m.Styles.ShortSeparator.Inline(true).Render(m.ShortSeparator)
+	)
 
-	for i, kb := range bindings {
+	for _, kb := range bindings {
 		if !kb.Enabled() {
 			continue
 		}
 
 		var sep string
-		if totalWidth > 0 && i < len(bindings) {
+		if totalWidth > 0 {
 			sep = separator
 		}
 
-		str := sep +
-			m.Styles.ShortKey.Inline(true).Render(kb.Help().Key) + " " +
+		item := m.Styles.ShortKey.Inline(true).Render(kb.Help().Key) + " " +
 			m.Styles.ShortDesc.Inline(true).Render(kb.Help().Desc)
 
-		w := lipgloss.Width(str)
+		w := lipgloss.Width(sep + item)
 
-		// If adding this help item would go over the available width, stop
-		// drawing.
+		// If adding this help item would go over the available width and
+		// we're allowed more lines, wrap onto the next one.
+		if m.Width > 0 && totalWidth+w > m.Width && totalWidth > 0 && len(lines) < m.ShortHelpMaxLines-1 {
+			lines = append(lines, b.String())
+			b.Reset()
+			totalWidth = 0
+			sep = ""
+			w = lipgloss.Width(item)
+		}
+
+		// If adding this help item would still go over the available width,
+		// stop drawing.
 		if m.Width > 0 && totalWidth+w > m.Width {
 			// Although if there's room for an ellipsis, print that.
 			tail := " " + m.Styles.Ellipsis.Inline(true).Render(m.Ellipsis)
@@ -151,77 +183,155 @@ func (m Model) ShortHelpView(bindings []key.Binding) string {
 		}
 
 		totalWidth += w
-		b.WriteString(str)
+		b.WriteString(sep + item)
 	}
 
-	return b.String()
+	return strings.Join(append(lines, b.String()), "\n")
 }
 
 // FullHelpView renders help columns from a slice of key binding slices. Each
-// top level slice entry renders into a column.
+// top level slice entry renders into a column. If Width is set, columns which
+// would overflow it wrap onto a new row beneath the others.
 func (m Model) FullHelpView(groups [][]key.Binding) string {
 	if len(groups) == 0 {
 		return ""
 	}
 
-	var (
-		// Linter note: at this time we don't think it's worth the additional
-		// code complexity involved in preallocating this slice.
-		//
-		//nolint:prealloc
-		out []string
-
-		totalWidth int
-		sep        = m.Styles.FullSeparator.Render(m.FullSeparator)
-		sepWidth   = lipgloss.Width(sep)
-	)
+	// Linter note: at this time we don't think it's worth the additional
+	// code complexity involved in preallocating this slice.
+	//
+	//nolint:prealloc
+	var cols []string
 
 	// Iterate over groups to build columns
-	for i, group := range groups {
+	for _, group := range groups {
 		if group == nil || !shouldRenderColumn(group) {
 			continue
 		}
+		cols = append(cols, m.fullHelpColumn(group))
+	}
 
-		var (
-			keys         []string
-			descriptions []string
-		)
+	return m.joinColumns(cols)
+}
 
-		// Separate keys and descriptions into different slices
-		for _, kb := range group {
-			if !kb.Enabled() {
-				continue
-			}
-			keys = append(keys, kb.Help().Key)
-			descriptions = append(descriptions, kb.Help().Desc)
+// FullHelpFlowView renders a flat slice of key bindings as full help, flowing
+// them into as many columns as fit within Width, up to FullHelpMaxColumns.
+// The bindings are spread evenly so the columns are roughly the same height.
+// If neither Width nor FullHelpMaxColumns is set a single column is rendered.
+func (m Model) FullHelpFlowView(bindings []key.Binding) string {
+	var enabled []key.Binding
+	for _, kb := range bindings {
+		if kb.Enabled() {
+			enabled = append(enabled, kb)
 		}
+	}
+	if len(enabled) == 0 {
+		return ""
+	}
 
-		col := lipgloss.JoinHorizontal(lipgloss.Top,
-			m.Styles.FullKey.Render(strings.Join(keys, "\n")),
-			m.Styles.FullKey.Render(" "),
-			m.Styles.FullDesc.Render(strings.Join(descriptions, "\n")),
-		)
+	n := len(enabled)
+	if m.FullHelpMaxColumns > 0 && m.FullHelpMaxColumns < n {
+		n = m.FullHelpMaxColumns
+	} else if m.Width <= 0 {
+		n = 1
+	}
 
-		// Column
-		totalWidth += lipgloss.Width(col)
-		if totalWidth > m.Width {
+	// Try the most columns first, settling on the first layout that fits.
+	var cols []string
+	for ; n > 0; n-- {
+		cols = m.flowColumns(enabled, n)
+		if m.Width <= 0 || n == 1 || m.rowWidth(cols) <= m.Width {
 			break
 		}
+	}
 
-		out = append(out, col)
+	return m.joinColumns(cols)
+}
 
-		// Separator
-		if i < len(group)-1 {
-			totalWidth += sepWidth
-			if totalWidth > m.Width {
-				break
-			}
+// flowColumns renders the bindings into n columns of roughly equal height.
+func (m Model) flowColumns(bindings []key.Binding, n int) []string {
+	height := (len(bindings) + n - 1) / n
+	cols := make([]string, 0, n)
+	for i := 0; i < len(bindings); i += height {
+		end := i + height
+		if end > len(bindings) {
+			end = len(bindings)
 		}
+		cols = append(cols, m.fullHelpColumn(bindings[i:end]))
+	}
+	return cols
+}
 
-		out = append(out, sep)
+// fullHelpColumn renders the enabled bindings in a group as a column of keys
+// and descriptions.
+func (m Model) fullHelpColumn(group []key.Binding) string {
+	var (
+		keys         []string
+		descriptions []string
+	)
+
+	// Separate keys and descriptions into different slices
+	for _, kb := range group {
+		if !kb.Enabled() {
+			continue
+		}
+		keys = append(keys, kb.Help().Key)
+		descriptions = append(descriptions, kb.Help().Desc)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.Styles.FullKey.Render(strings.Join(keys, "\n")),
+		m.Styles.FullKey.Render(" "),
+		m.Styles.FullDesc.Render(strings.Join(descriptions, "\n")),
+	)
+}
+
+// joinColumns lays out full help columns side by side, separated by the full
+// separator. If Width is set, columns which would overflow it wrap onto a new
+// row.
+func (m Model) joinColumns(cols []string) string {
+	var (
+		rows     []string
+		row      []string
+		rowWidth int
+		sep      = m.Styles.FullSeparator.Render(m.FullSeparator)
+		sepWidth = lipgloss.Width(sep)
+	)
+
+	for _, col := range cols {
+		w := lipgloss.Width(col)
+		if len(row) > 0 {
+			w += sepWidth
+		}
+
+		if m.Width > 0 && len(row) > 0 && rowWidth+w > m.Width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+			rowWidth = 0
+			w = lipgloss.Width(col)
+		}
+
+		if len(row) > 0 {
+			row = append(row, sep)
+		}
+		row = append(row, col)
+		rowWidth += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, out...)
+	// Leave a blank line between rows
+	return strings.Join(rows, "\n\n")
+}
+
+// rowWidth returns the width of the given columns laid out on a single row.
+func (m Model) rowWidth(cols []string) int {
+	w := lipgloss.Width(m.Styles.FullSeparator.Render(m.FullSeparator)) * (len(cols) - 1)
+	for _, col := range cols {
+		w += lipgloss.Width(col)
+	}
+	return w
 }
 
 func shouldRenderColumn(b []key.Binding) (ok bool) {
@@ -0,0 +1,75 @@
+package key
+
+import "encoding/json"
+
+// jsonBinding is the JSON representation of a Binding.
+type jsonBinding struct {
+	Keys     []string  `json:"keys"`
+	Help     *jsonHelp `json:"help,omitempty"`
+	Disabled bool      `json:"disabled,omitempty"`
+}
+
+// jsonHelp is the JSON representation of Help.
+type jsonHelp struct {
+	Key  string `json:"key"`
+	Desc string `json:"desc"`
+}
+
+// MarshalJSON encodes the keybinding's keys, help text and whether or not
+// it's disabled as JSON. For example:
+//
+//     {"keys":["k","up"],"help":{"key":"↑/k","desc":"move up"}}
+//
+// Help is omitted if the keybinding has none, and disabled is only included
+// when the keybinding is disabled.
+func (b Binding) MarshalJSON() ([]byte, error) {
+	j := jsonBinding{
+		Keys:     b.keys,
+		Disabled: b.disabled,
+	}
+	if j.Keys == nil {
+		j.Keys = []string{}
+	}
+	if b.help != (Help{}) {
+		j.Help = &jsonHelp{Key: b.help.Key, Desc: b.help.Desc}
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes a keybinding encoded with MarshalJSON, replacing the
+// keybinding's keys, help text and enabled state. This makes it possible to
+// load user-defined keymaps from a config file:
+//
+//     type KeyMap struct {
+//         Up   key.Binding `json:"up"`
+//         Down key.Binding `json:"down"`
+//     }
+//
+//     keys := DefaultKeyMap
+//     f, err := os.Open("keys.json")
+//     if err != nil {
+//         return err
+//     }
+//     defer f.Close()
+//
+//     // Bindings missing from the file keep their defaults
+//     if err := json.NewDecoder(f).Decode(&keys); err != nil {
+//         return err
+//     }
+func (b *Binding) UnmarshalJSON(data []byte) error {
+	var j jsonBinding
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	b.keys = nil
+	if len(j.Keys) > 0 {
+		b.keys = j.Keys
+	}
+	b.help = Help{}
+	if j.Help != nil {
+		b.help = Help{Key: j.Help.Key, Desc: j.Help.Desc}
+	}
+	b.disabled = j.Disabled
+	return nil
+}
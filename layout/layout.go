@@ -0,0 +1,164 @@
+// Package layout provides helpers for dividing an area of the terminal between
+// several components. It doesn't render anything: it simply does the width and
+// height arithmetic so you can pass the results to each component's SetSize
+// (or Width and Height) methods. For example, to place a list beside a
+// viewport:
+//
+//     panes := layout.HSplit(layout.NewRect(msg.Width, msg.Height), 1,
+//         layout.Fixed(30),
+//         layout.Ratio(1),
+//     )
+//     m.list.SetSize(panes[0].Size())
+//     m.viewport.Width, m.viewport.Height = panes[1].Inner(m.viewport.Style).Size()
+//
+package layout
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rect is a rectangular area of the terminal, measured in cells.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// NewRect returns a rect of the given size positioned at the origin.
+func NewRect(width, height int) Rect {
+	return Rect{Width: max(0, width), Height: max(0, height)}
+}
+
+// Size returns the width and height of the rect. Its signature matches the
+// arguments of the SetSize methods found on components like list.Model.
+func (r Rect) Size() (width, height int) {
+	return r.Width, r.Height
+}
+
+// Inner returns the area left over inside of the given style's frame, that is,
+// after its margins, borders and padding have been accounted for. Use this to
+// size a component that will be rendered with a border around it.
+func (r Rect) Inner(s lipgloss.Style) Rect {
+	top := s.GetMarginTop() + s.GetBorderTopWidth() + s.GetPaddingTop()
+	left := s.GetMarginLeft() + s.GetBorderLeftSize() + s.GetPaddingLeft()
+	x, y := s.GetFrameSize()
+	return Rect{
+		X:      r.X + left,
+		Y:      r.Y + top,
+		Width:  max(0, r.Width-x),
+		Height: max(0, r.Height-y),
+	}
+}
+
+// Size describes how much space a pane should take up in a split. Use Fixed
+// and Ratio to create sizes.
+type Size struct {
+	fixed int
+	ratio float64
+}
+
+// Fixed returns a size of exactly n cells. If there isn't enough room for all
+// fixed sizes in a split, the panes at the end of the split are shrunk first.
+func Fixed(n int) Size {
+	return Size{fixed: max(0, n)}
+}
+
+// Ratio returns a size which takes a proportional share of the space left
+// over after fixed sizes and gaps have been allocated. For example, two panes
+// of Ratio(1) and Ratio(2) will get one third and two thirds of the remaining
+// space respectively.
+func Ratio(r float64) Size {
+	return Size{ratio: math.Max(0, r)}
+}
+
+// HSplit divides the given rect into panes laid out left-to-right, separated
+// by gap cells. A negative gap is treated as 0. One rect is returned per size,
+// in the same order.
+func HSplit(r Rect, gap int, sizes ...Size) []Rect {
+	gap = max(0, gap)
+	rects := make([]Rect, len(sizes))
+	x := r.X
+	for i, w := range split(r.Width, gap, sizes) {
+		rects[i] = Rect{X: x, Y: r.Y, Width: w, Height: r.Height}
+		x += w + gap
+	}
+	return rects
+}
+
+// VSplit divides the given rect into panes stacked top-to-bottom, separated
+// by gap cells. A negative gap is treated as 0. One rect is returned per size,
+// in the same order.
+func VSplit(r Rect, gap int, sizes ...Size) []Rect {
+	gap = max(0, gap)
+	rects := make([]Rect, len(sizes))
+	y := r.Y
+	for i, h := range split(r.Height, gap, sizes) {
+		rects[i] = Rect{X: r.X, Y: y, Width: r.Width, Height: h}
+		y += h + gap
+	}
+	return rects
+}
+
+// split allocates total cells between the given sizes, which are separated by
+// gap cells. If any sizes are ratios the results add up to total (minus the
+// gaps), so rounding never leaves stray columns. Otherwise any space the fixed
+// sizes don't use is left over.
+func split(total, gap int, sizes []Size) []int {
+	out := make([]int, len(sizes))
+	if len(sizes) == 0 {
+		return out
+	}
+
+	avail := max(0, total-gap*(len(sizes)-1))
+
+	// Fixed sizes first
+	var ratioSum float64
+	for i, s := range sizes {
+		if s.ratio > 0 {
+			ratioSum += s.ratio
+			continue
+		}
+		out[i] = min(s.fixed, avail)
+		avail -= out[i]
+	}
+
+	if ratioSum == 0 {
+		return out
+	}
+
+	// Distribute what's left according to the ratios. Rounding the running
+	// total, rather than each pane individually, makes sure the remainder
+	// is spread evenly and nothing is lost.
+	var (
+		acc  float64
+		prev int
+	)
+	for i, s := range sizes {
+		if s.ratio <= 0 {
+			continue
+		}
+		acc += s.ratio
+		next := int(math.Round(float64(avail) * acc / ratioSum))
+		out[i] = next - prev
+		prev = next
+	}
+
+	return out
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		gap   int
+		sizes []Size
+		want  []Rect
+	}{
+		{
+			name:  "fixed and ratio",
+			width: 80, gap: 1,
+			sizes: []Size{Fixed(30), Ratio(1)},
+			want:  []Rect{{0, 0, 30, 10}, {31, 0, 49, 10}},
+		},
+		{
+			name:  "ratios round without losing cells",
+			width: 10, gap: 0,
+			sizes: []Size{Ratio(1), Ratio(1), Ratio(1)},
+			want:  []Rect{{0, 0, 3, 10}, {3, 0, 4, 10}, {7, 0, 3, 10}},
+		},
+		{
+			name:  "uneven ratios",
+			width: 31, gap: 1,
+			sizes: []Size{Ratio(1), Ratio(2)},
+			want:  []Rect{{0, 0, 10, 10}, {11, 0, 20, 10}},
+		},
+		{
+			name:  "fixed only leaves space over",
+			width: 80, gap: 2,
+			sizes: []Size{Fixed(10), Fixed(20)},
+			want:  []Rect{{0, 0, 10, 10}, {12, 0, 20, 10}},
+		},
+		{
+			name:  "fixed sizes shrink from the end",
+			width: 25, gap: 1,
+			sizes: []Size{Fixed(20), Fixed(20)},
+			want:  []Rect{{0, 0, 20, 10}, {21, 0, 4, 10}},
+		},
+		{
+			name:  "negative gap is treated as zero",
+			width: 20, gap: -3,
+			sizes: []Size{Ratio(1), Ratio(1)},
+			want:  []Rect{{0, 0, 10, 10}, {10, 0, 10, 10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HSplit(NewRect(tt.width, 10), tt.gap, tt.sizes...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVSplit(t *testing.T) {
+	got := VSplit(Rect{X: 5, Y: 2, Width: 40, Height: 21}, -1, Fixed(3), Ratio(1), Fixed(2))
+	want := []Rect{{5, 2, 40, 3}, {5, 5, 40, 16}, {5, 21, 40, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestInner(t *testing.T) {
+	s := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		Padding(0, 1).
+		Margin(1, 0, 0, 2)
+
+	got := Rect{X: 10, Y: 5, Width: 30, Height: 10}.Inner(s)
+	want := Rect{X: 14, Y: 7, Width: 24, Height: 7}
+	if got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// The frame can't make the rect negative
+	if got := NewRect(2, 1).Inner(s); got.Width != 0 || got.Height != 0 {
+		t.Errorf("expected an empty rect, got %v", got)
+	}
+}
@@ -3,11 +3,15 @@ package list
 import (
 	"fmt"
 	"io"
+	"strings"
+	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lorenries/bubbles/key"
 	"github.com/muesli/reflow/truncate"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
 )
 
 // DefaultItemStyles defines styling for a default list item.
@@ -16,14 +20,22 @@ type DefaultItemStyles struct {
 	// The Normal state.
 	NormalTitle lipgloss.Style
 	NormalDesc  lipgloss.Style
+	NormalMeta  lipgloss.Style
 
 	// The selected item state.
 	SelectedTitle lipgloss.Style
 	SelectedDesc  lipgloss.Style
+	SelectedMeta  lipgloss.Style
+
+	// The checked state, for items selected when multi-select is enabled
+	// that aren't under the cursor.
+	CheckedTitle lipgloss.Style
+	CheckedDesc  lipgloss.Style
 
 	// The dimmed state, for when the filter input is initially activated.
 	DimmedTitle lipgloss.Style
 	DimmedDesc  lipgloss.Style
+	DimmedMeta  lipgloss.Style
 
 	// Charcters matching the current filter, if any.
 	FilterMatch lipgloss.Style
@@ -39,6 +51,9 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 	s.NormalDesc = s.NormalTitle.Copy().
 		Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"})
 
+	s.NormalMeta = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"})
+
 	s.SelectedTitle = lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder(), false, false, false, true).
 		BorderForeground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"}).
@@ -48,6 +63,15 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 	s.SelectedDesc = s.SelectedTitle.Copy().
 		Foreground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"})
 
+	s.SelectedMeta = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"})
+
+	s.CheckedTitle = s.NormalTitle.Copy().
+		Foreground(lipgloss.AdaptiveColor{Light: "#04B575", Dark: "#04B575"})
+
+	s.CheckedDesc = s.NormalDesc.Copy().
+		Foreground(lipgloss.AdaptiveColor{Light: "#5DD2A4", Dark: "#3C8D6D"})
+
 	s.DimmedTitle = lipgloss.NewStyle().
 		Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"}).
 		Padding(0, 0, 0, 2)
@@ -55,11 +79,21 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 	s.DimmedDesc = s.DimmedTitle.Copy().
 		Foreground(lipgloss.AdaptiveColor{Light: "#C2B8C2", Dark: "#4D4D4D"})
 
+	s.DimmedMeta = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#C2B8C2", Dark: "#4D4D4D"})
+
 	s.FilterMatch = lipgloss.NewStyle().Underline(true)
 
 	return s
 }
 
+// Highlight describes a term which should be styled wherever it appears in an
+// item's title, independent of any filtering. Matching is case-insensitive.
+type Highlight struct {
+	Term  string
+	Style lipgloss.Style
+}
+
 // DefaultItem describes an items designed to work with DefaultDelegate.
 type DefaultItem interface {
 	Item
@@ -67,6 +101,14 @@ type DefaultItem interface {
 	Description() string
 }
 
+// DefaultItemWithMeta describes a DefaultItem with additional metadata, such
+// as a timestamp or file size. DefaultDelegate renders the metadata pinned to
+// the right edge of the title line, truncating the title to make room.
+type DefaultItemWithMeta interface {
+	DefaultItem
+	Meta() string
+}
+
 // DefaultDelegate is a standard delegate designed to work in lists. It's
 // styled by DefaultItemStyles, which can be customized as you like.
 //
@@ -74,6 +116,10 @@ type DefaultItem interface {
 // renders the list as single-line-items. The spacing between items can be set
 // with the SetSpacing method.
 //
+// Descriptions can span several lines by setting DescriptionLines. They're
+// wrapped to fit, and anything that doesn't fit is truncated with Ellipsis on
+// the last line.
+//
 // Setting UpdateFunc is optional. If it's set it will be called when the
 // ItemDelegate called, which is called when the list's Update function is
 // invoked.
@@ -84,33 +130,93 @@ type DefaultItem interface {
 //
 // Settings ShortHelpFunc and FullHelpFunc is optional. They can can be set to
 // include items in the list's default short and full help menus.
+//
+// Highlights are optional. Terms added with the Highlight method will be
+// styled in item titles whether or not the list is being filtered.
+//
+// Setting MatchStyleFunc is optional. If it's set it will be called for every
+// rune in the title while filtering, and the returned style is layered over
+// the title style. This allows for richer match highlighting than the single
+// FilterMatch style, such as coloring matches by position.
+//
+// Titles and descriptions which don't fit the width of the list are truncated
+// and marked with Ellipsis, or "…" if it's empty. Set WrapTitle or WrapDesc to
+// wrap them onto multiple lines instead; items then grow as tall as they need
+// to be. Widths are measured in terminal cells, so wide characters such as CJK
+// and emoji are accounted for.
+//
+// When the list has multi-select enabled, items are prefixed with a check
+// mark when they're selected, and selected items not under the cursor are
+// rendered with the checked styles.
 type DefaultDelegate struct {
-	ShowDescription bool
-	Styles          DefaultItemStyles
-	UpdateFunc      func(tea.Msg, *Model) tea.Cmd
-	RenderFunc      func(w io.Writer, m Model, index int, item Item)
-	ShortHelpFunc   func() []key.Binding
-	FullHelpFunc    func() [][]key.Binding
-	spacing         int
+	ShowDescription  bool
+	DescriptionLines int
+	Ellipsis         string
+	WrapTitle        bool
+	WrapDesc         bool
+	Styles           DefaultItemStyles
+	Highlights       []Highlight
+	MatchStyleFunc   func(runeIndex int, matched bool) lipgloss.Style
+	UpdateFunc       func(tea.Msg, *Model) tea.Cmd
+	RenderFunc       func(w io.Writer, m Model, index int, item Item)
+	ShortHelpFunc    func() []key.Binding
+	FullHelpFunc     func() [][]key.Binding
+	spacing          int
 }
 
 // NewDefaultDelegate creates a new delegate with default styles.
 func NewDefaultDelegate() DefaultDelegate {
 	return DefaultDelegate{
-		ShowDescription: true,
-		Styles:          NewDefaultItemStyles(),
-		spacing:         1,
+		ShowDescription:  true,
+		DescriptionLines: 1,
+		Ellipsis:         ellipsis,
+		Styles:           NewDefaultItemStyles(),
+		spacing:          1,
 	}
 }
 
 // Height returns the delegate's preferred height.
 func (d DefaultDelegate) Height() int {
 	if d.ShowDescription {
-		return 2 //nolint:gomnd
+		return 1 + d.descriptionLines()
 	}
 	return 1
 }
 
+// descriptionLines returns the number of lines descriptions take up, which is
+// always at least one.
+func (d DefaultDelegate) descriptionLines() int {
+	return max(1, d.DescriptionLines)
+}
+
+// ellipsis returns the tail marking truncated text.
+func (d DefaultDelegate) ellipsis() string {
+	if d.Ellipsis == "" {
+		return ellipsis
+	}
+	return d.Ellipsis
+}
+
+// wraps returns whether or not items can wrap onto more lines than Height.
+// When they can't the list paginates items as fixed height, even though the
+// delegate implements VariableHeightDelegate.
+func (d DefaultDelegate) wraps() bool {
+	return d.RenderFunc == nil && (d.WrapTitle || (d.ShowDescription && d.WrapDesc))
+}
+
+// HeightForItem returns the height of the given item. Items are Height lines
+// tall unless the title or description wraps. It's part of the
+// VariableHeightDelegate interface.
+func (d DefaultDelegate) HeightForItem(m Model, index int, item Item) int {
+	if !d.wraps() || m.width <= 0 {
+		return d.Height()
+	}
+
+	var b strings.Builder
+	d.Render(&b, m, index, item)
+	return max(d.Height(), strings.Count(b.String(), "\n")+1)
+}
+
 // SetSpacing set the delegate's spacing.
 func (d *DefaultDelegate) SetSpacing(i int) {
 	d.spacing = i
@@ -121,6 +227,26 @@ func (d DefaultDelegate) Spacing() int {
 	return d.spacing
 }
 
+// Highlight styles the given term wherever it appears in item titles. If the
+// term is already highlighted its style is replaced.
+func (d *DefaultDelegate) Highlight(term string, style lipgloss.Style) {
+	if term == "" {
+		return
+	}
+	for i, h := range d.Highlights {
+		if h.Term == term {
+			d.Highlights[i].Style = style
+			return
+		}
+	}
+	d.Highlights = append(d.Highlights, Highlight{Term: term, Style: style})
+}
+
+// ClearHighlights removes all highlighted terms.
+func (d *DefaultDelegate) ClearHighlights() {
+	d.Highlights = nil
+}
+
 // Update checks whether the delegate's UpdateFunc is set and calls it.
 func (d DefaultDelegate) Update(msg tea.Msg, m *Model) tea.Cmd {
 	if d.UpdateFunc == nil {
@@ -137,9 +263,9 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 	}
 
 	var (
-		title, desc  string
-		matchedRunes []int
-		s            = &d.Styles
+		title, desc, meta string
+		matchedRunes      []int
+		s                 = &d.Styles
 	)
 
 	if i, ok := item.(DefaultItem); ok {
@@ -149,11 +275,74 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 		return
 	}
 
+	if i, ok := item.(DefaultItemWithMeta); ok {
+		meta = i.Meta()
+	}
+
+	// In tree mode, indent items by their depth and indicate whether they can
+	// be expanded.
+	var titlePrefix, descPrefix string
+	if m.TreeMode() {
+		info := m.TreeInfo(index)
+		titlePrefix = strings.Repeat(treeIndent, info.Depth)
+		switch {
+		case info.HasChildren && info.Expanded:
+			titlePrefix += treeExpanded
+		case info.HasChildren:
+			titlePrefix += treeCollapsed
+		default:
+			titlePrefix += treeLeaf
+		}
+	}
+
+	// With multi-select enabled, indicate whether the item is selected
+	isChecked := m.MultiSelect() && m.isVisibleItemSelected(index)
+	if m.MultiSelect() {
+		if isChecked {
+			titlePrefix = checkMark + titlePrefix
+		} else {
+			titlePrefix = uncheckMark + titlePrefix
+		}
+	}
+	descPrefix = strings.Repeat(" ", lipgloss.Width(titlePrefix))
+
 	// Prevent text from exceeding list width
+	var titleWrapWidth int
 	if m.width > 0 {
-		textwidth := uint(m.width - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight())
-		title = truncate.StringWithTail(title, textwidth, ellipsis)
-		desc = truncate.StringWithTail(desc, textwidth, ellipsis)
+		textwidth := max(0, m.width-s.NormalTitle.GetPaddingLeft()-s.NormalTitle.GetPaddingRight()-lipgloss.Width(titlePrefix))
+		titlewidth := textwidth
+
+		// Make room for the metadata, if any. If there's no room at all for
+		// the title, drop the metadata.
+		if meta != "" {
+			metaWidth := lipgloss.Width(s.NormalMeta.Render(meta)) + len(metaGap)
+			if metaWidth < titlewidth {
+				titlewidth -= metaWidth
+			} else {
+				meta = ""
+			}
+		}
+
+		// Titles are wrapped once they've been styled, so filter matches
+		// line up with the original text.
+		if d.WrapTitle {
+			titleWrapWidth = titlewidth
+		} else {
+			title = truncateText(title, titlewidth, d.ellipsis())
+		}
+		switch {
+		case d.WrapDesc:
+			desc = wrapText(desc, textwidth)
+		case d.descriptionLines() > 1:
+			desc = truncateLines(desc, textwidth, d.descriptionLines(), d.ellipsis())
+		default:
+			desc = truncateText(desc, textwidth, d.ellipsis())
+		}
+	}
+
+	// Keep the height consistent, even for short descriptions
+	if n := strings.Count(desc, "\n") + 1; n < d.descriptionLines() {
+		desc += strings.Repeat("\n", d.descriptionLines()-n)
 	}
 
 	// Conditions
@@ -168,27 +357,40 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 		matchedRunes = m.MatchesForItem(index)
 	}
 
+	var (
+		titleStyle = s.NormalTitle
+		descStyle  = s.NormalDesc
+		metaStyle  = s.NormalMeta
+	)
+
 	if emptyFilter {
-		title = s.DimmedTitle.Render(title)
-		desc = s.DimmedDesc.Render(desc)
+		titleStyle, descStyle, metaStyle = s.DimmedTitle, s.DimmedDesc, s.DimmedMeta
+		matchedRunes = nil
 	} else if isSelected && m.FilterState() != Filtering {
-		if isFiltered {
-			// Highlight matches
-			unmatched := s.SelectedTitle.Inline(true)
-			matched := unmatched.Copy().Inherit(s.FilterMatch)
-			title = lipgloss.StyleRunes(title, matchedRunes, matched, unmatched)
-		}
-		title = s.SelectedTitle.Render(title)
-		desc = s.SelectedDesc.Render(desc)
-	} else {
-		if isFiltered {
-			// Highlight matches
-			unmatched := s.NormalTitle.Inline(true)
-			matched := unmatched.Copy().Inherit(s.FilterMatch)
-			title = lipgloss.StyleRunes(title, matchedRunes, matched, unmatched)
+		titleStyle, descStyle, metaStyle = s.SelectedTitle, s.SelectedDesc, s.SelectedMeta
+	} else if isChecked {
+		titleStyle, descStyle = s.CheckedTitle, s.CheckedDesc
+	}
+
+	title = d.styleTitle(title, matchedRunes, titleStyle)
+	if titleWrapWidth > 0 {
+		title = wrapText(title, titleWrapWidth)
+	}
+
+	// Wrapped lines are aligned with the text on the first line
+	title = titleStyle.Render(titlePrefix + strings.ReplaceAll(title, "\n", "\n"+descPrefix))
+	desc = descStyle.Render(descPrefix + strings.ReplaceAll(desc, "\n", "\n"+descPrefix))
+
+	// Pin the metadata to the right edge of the first line
+	if meta != "" {
+		meta = metaStyle.Render(meta)
+		lines := strings.SplitN(title, "\n", 2) //nolint:gomnd
+		gap := len(metaGap)
+		if m.width > 0 {
+			gap = max(gap, m.width-lipgloss.Width(lines[0])-lipgloss.Width(meta))
 		}
-		title = s.NormalTitle.Render(title)
-		desc = s.NormalDesc.Render(desc)
+		lines[0] += strings.Repeat(" ", gap) + meta
+		title = strings.Join(lines, "\n")
 	}
 
 	if d.ShowDescription {
@@ -198,6 +400,143 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 	fmt.Fprintf(w, "%s", title)
 }
 
+// truncateText truncates s to the given width in cells, ending it with tail if
+// it's cut. Text that fits is left untouched. When a wide character straddles
+// the cut the gap is padded, so the tail always ends at the given width.
+func truncateText(s string, width int, tail string) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+
+	textWidth := width - lipgloss.Width(tail)
+	if textWidth < 0 {
+		return truncate.StringWithTail(s, uint(max(0, width)), tail)
+	}
+	s = truncate.String(s, uint(textWidth))
+	return s + strings.Repeat(" ", max(0, textWidth-lipgloss.Width(s))) + tail
+}
+
+// truncateLines wraps s to the given width in cells and keeps at most n lines.
+// If any text is cut the last line is ended with tail.
+func truncateLines(s string, width, n int, tail string) string {
+	lines := strings.Split(wrapText(s, width), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	lines = lines[:n]
+	lines[n-1] = truncateText(lines[n-1], width-lipgloss.Width(tail), "") + tail
+	return strings.Join(lines, "\n")
+}
+
+// wrapText wraps s to the given width in cells, breaking at word boundaries
+// where possible and within words that are too long to fit on a line.
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return wrap.String(wordwrap.String(s, width), width)
+}
+
+// styleTitle highlights runes in the title matched by the current filter as
+// well as any runes belonging to highlighted terms. Highlight styles take
+// precedence over the base style, and filter matches are layered on top of
+// both.
+func (d DefaultDelegate) styleTitle(title string, matchedRunes []int, base lipgloss.Style) string {
+	highlighted := highlightRunes(title, d.Highlights)
+	if len(matchedRunes) == 0 && len(highlighted) == 0 {
+		return title
+	}
+
+	matched := make(map[int]struct{}, len(matchedRunes))
+	for _, i := range matchedRunes {
+		matched[i] = struct{}{}
+	}
+
+	// Each rune belongs to a class: which highlight, if any, it's part of and
+	// whether or not it was matched by the filter. Consecutive runes of the
+	// same class are rendered together.
+	type class struct {
+		highlight int // index into d.Highlights, or -1
+		matched   bool
+	}
+	classOf := func(i int) class {
+		c := class{highlight: -1}
+		if h, ok := highlighted[i]; ok {
+			c.highlight = h
+		}
+		_, c.matched = matched[i]
+		return c
+	}
+
+	var (
+		out    strings.Builder
+		group  strings.Builder
+		runes  = []rune(title)
+		inline = base.Copy().Inline(true)
+
+		// When a MatchStyleFunc is in play the style can differ from rune to
+		// rune, so we can't group runes together.
+		perRune = d.MatchStyleFunc != nil && len(matchedRunes) > 0
+	)
+
+	for i, r := range runes {
+		group.WriteRune(r)
+
+		c := classOf(i)
+		if !perRune && i < len(runes)-1 && classOf(i+1) == c {
+			continue
+		}
+
+		// Flush
+		style := inline
+		if c.highlight >= 0 {
+			style = d.Highlights[c.highlight].Style.Copy().Inline(true).Inherit(inline)
+		}
+		if perRune {
+			style = d.MatchStyleFunc(i, c.matched).Copy().Inline(true).Inherit(style)
+		} else if c.matched {
+			style = style.Copy().Inherit(d.Styles.FilterMatch)
+		}
+		out.WriteString(style.Render(group.String()))
+		group.Reset()
+	}
+
+	return out.String()
+}
+
+// highlightRunes returns a map of rune indices in s to the index of the
+// highlight covering them. When highlights overlap the earlier one wins.
+func highlightRunes(s string, highlights []Highlight) map[int]int {
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+
+	out := make(map[int]int)
+	for h := len(highlights) - 1; h >= 0; h-- {
+		term := []rune(highlights[h].Term)
+		for i, r := range term {
+			term[i] = unicode.ToLower(r)
+		}
+		if len(term) == 0 {
+			continue
+		}
+		for i := 0; i+len(term) <= len(runes); i++ {
+			if string(runes[i:i+len(term)]) != string(term) {
+				continue
+			}
+			for j := i; j < i+len(term); j++ {
+				out[j] = h
+			}
+		}
+	}
+	return out
+}
+
 // ShortHelp returns the delegate's short help.
 func (d DefaultDelegate) ShortHelp() []key.Binding {
 	if d.ShortHelpFunc != nil {
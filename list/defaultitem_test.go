@@ -0,0 +1,92 @@
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncateTextWideRunes(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"cjk", "日本語のテキストです"},
+		{"emoji", "emoji 😀😀😀😀 title"},
+		{"mixed", "abc日本語def"},
+	}
+
+	for _, tt := range tests {
+		for width := 2; width < lipgloss.Width(tt.text); width++ {
+			got := truncateText(tt.text, width, ellipsis)
+			if w := lipgloss.Width(got); w != width {
+				t.Errorf("%s: truncating to %d cells gave %q, which is %d cells wide", tt.name, width, got, w)
+			}
+			if !strings.HasSuffix(got, ellipsis) {
+				t.Errorf("%s: truncating to %d cells gave %q, which doesn't end with an ellipsis", tt.name, width, got)
+			}
+		}
+
+		// Text which fits is left alone
+		if got := truncateText(tt.text, lipgloss.Width(tt.text), ellipsis); got != tt.text {
+			t.Errorf("%s: expected text which fits to be untouched, got %q", tt.name, got)
+		}
+	}
+}
+
+func TestDefaultDelegateTruncatesWideTitles(t *testing.T) {
+	const width = 12
+
+	d := NewDefaultDelegate()
+	d.ShowDescription = false
+	m := New([]Item{testItem{title: "日本語のテキストです"}}, d, width, 10)
+
+	var b strings.Builder
+	d.Render(&b, m, 0, m.Items()[0])
+
+	// The title, its padding and the ellipsis fill the width exactly
+	if w := lipgloss.Width(b.String()); w != width {
+		t.Errorf("expected the item to be %d cells wide, got %q (%d cells)", width, b.String(), w)
+	}
+	if !strings.Contains(b.String(), ellipsis) {
+		t.Errorf("expected the title to be truncated, got %q", b.String())
+	}
+}
+
+func TestZeroValueDelegateTruncates(t *testing.T) {
+	const width = 20
+
+	d := DefaultDelegate{Styles: NewDefaultItemStyles()}
+	m := New([]Item{
+		testItem{title: "first"},
+		testItem{title: strings.Repeat("word ", 10)},
+	}, d, width, 10)
+
+	var b strings.Builder
+	d.Render(&b, m, 1, m.Items()[1])
+
+	if strings.Contains(b.String(), "\n") {
+		t.Errorf("expected the title to stay on one line, got %q", b.String())
+	}
+	if !strings.HasSuffix(b.String(), ellipsis) {
+		t.Errorf("expected the title to end with %q, got %q", ellipsis, b.String())
+	}
+	if w := lipgloss.Width(b.String()); w != width {
+		t.Errorf("expected the item to be %d cells wide, got %d", width, w)
+	}
+
+	// Items which can't wrap are paginated as fixed height
+	if m.pageStarts != nil {
+		t.Errorf("expected fixed height pagination, got page starts %v", m.pageStarts)
+	}
+	if m := New(testItems(10), NewDefaultDelegate(), width, 10); m.pageStarts != nil {
+		t.Errorf("expected fixed height pagination for the default delegate, got page starts %v", m.pageStarts)
+	}
+
+	// Wrapping opts in to variable height pagination
+	d.WrapTitle = true
+	if m := New(m.Items(), d, width, 10); m.pageStarts == nil {
+		t.Error("expected variable height pagination when wrapping titles")
+	}
+}
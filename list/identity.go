@@ -0,0 +1,119 @@
+package list
+
+// IdentifiableItem is an item with a stable identity. When items implement
+// this interface, SetItems keeps the cursor and the selection on the same
+// items, matched by ID, rather than at the same positions. This is useful for
+// lists which are refreshed with new data while the user is browsing them.
+//
+// IDs should be unique within the list.
+type IdentifiableItem interface {
+	Item
+	ID() string
+}
+
+// UpdateItem replaces the item with the given ID in place, without disturbing
+// the cursor, the selection or the filter. Only top-level items are
+// considered. Returns whether or not an item with the ID was found.
+func (m *Model) UpdateItem(id string, item Item) bool {
+	index := -1
+	for i, v := range m.items {
+		if vid, ok := itemID(v); ok && vid == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+
+	selectedIDs := m.selectedIDs()
+	m.items[index] = item
+	m.rebuildTree()
+
+	if m.treeMode {
+		// The item's children may have changed, which shifts the positions
+		// of the nodes after it
+		m.ClearSelection()
+		m.restoreSelection(selectedIDs)
+		if m.filterState != Unfiltered {
+			m.filteredItems = filteredItems(filterItems(*m)().(FilterMatchesMsg))
+		}
+		m.updatePagination()
+		return true
+	}
+
+	// Update the item in place among the filter matches, keeping its
+	// position even if it no longer matches
+	for i, f := range m.filteredItems {
+		if f.index != index {
+			continue
+		}
+		m.filteredItems[i].item = item
+		m.filteredItems[i].matches = nil
+		m.filteredItems[i].matched = false
+		m.filteredItems[i].score = 0
+		if term := m.FilterInput.Value(); term != "" && m.filterState != Unfiltered {
+			if ranks := m.filterFunc()(term, []string{item.FilterValue()}); len(ranks) > 0 {
+				m.filteredItems[i].matches = ranks[0].MatchedIndexes
+				m.filteredItems[i].matched = true
+				m.filteredItems[i].score = ranks[0].Score
+			}
+		}
+	}
+
+	m.updatePagination()
+	return true
+}
+
+// itemID returns the ID of the given item, if it has one.
+func itemID(item Item) (string, bool) {
+	if i, ok := item.(IdentifiableItem); ok {
+		return i.ID(), true
+	}
+	return "", false
+}
+
+// selectedIDs returns the IDs of the selected items that have one.
+func (m Model) selectedIDs() map[string]struct{} {
+	if len(m.selection) == 0 {
+		return nil
+	}
+	ids := make(map[string]struct{}, len(m.selection))
+	for i := range m.selection {
+		if id, ok := itemID(m.selectableItem(i)); ok {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
+
+// restoreSelection selects the items with the given IDs.
+func (m *Model) restoreSelection(ids map[string]struct{}) {
+	if len(ids) == 0 {
+		return
+	}
+	for i, n := 0, m.itemCount(); i < n; i++ {
+		id, ok := itemID(m.selectableItem(i))
+		if !ok {
+			continue
+		}
+		if _, ok := ids[id]; ok {
+			if m.selection == nil {
+				m.selection = make(map[int]struct{})
+			}
+			m.selection[i] = struct{}{}
+		}
+	}
+}
+
+// selectID moves the cursor to the visible item with the given ID. Returns
+// whether or not the item was found.
+func (m *Model) selectID(id string) bool {
+	for i, item := range m.VisibleItems() {
+		if vid, ok := itemID(item); ok && vid == id {
+			m.Select(i)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package list
+
+import "testing"
+
+type idItem struct {
+	testItem
+	id string
+}
+
+func (i idItem) ID() string { return i.id }
+
+// idItems returns items with the given IDs, titled after them.
+func idItems(ids ...string) []Item {
+	items := make([]Item, len(ids))
+	for i, id := range ids {
+		items[i] = idItem{testItem: testItem{title: id}, id: id}
+	}
+	return items
+}
+
+func TestSetItemsCursorFollowsItem(t *testing.T) {
+	m := New(idItems("a", "b", "c", "d", "e"), NewDefaultDelegate(), 80, 40)
+	m.Select(3)
+
+	m.SetItems(idItems("d", "e", "a", "b", "c"))
+	if got, _ := itemID(m.SelectedItem()); got != "d" || m.Index() != 0 {
+		t.Errorf("expected the cursor to follow item d to index 0, got item %q at index %d", got, m.Index())
+	}
+
+	// Items which are removed leave the cursor where it was
+	m.Select(2)
+	m.SetItems(idItems("d", "e", "b", "c"))
+	if m.Index() != 2 {
+		t.Errorf("expected the cursor to stay at index 2, got %d", m.Index())
+	}
+}
+
+func TestSetItemsCursorFollowsItemAcrossPages(t *testing.T) {
+	ids := make([]string, 30)
+	for i := range ids {
+		ids[i] = string(rune('A' + i))
+	}
+	m := New(idItems(ids...), NewDefaultDelegate(), 80, 20)
+	m.Select(0)
+
+	// Move the first item to the end, which is on a later page
+	m.SetItems(idItems(append(ids[1:len(ids):len(ids)], ids[0])...))
+	if got, _ := itemID(m.SelectedItem()); got != "A" || m.Index() != len(ids)-1 {
+		t.Errorf("expected the cursor to follow item A to index %d, got item %q at index %d", len(ids)-1, got, m.Index())
+	}
+	if m.Paginator.Page != m.Paginator.TotalPages-1 {
+		t.Errorf("expected to be on the last page, %d, got %d", m.Paginator.TotalPages-1, m.Paginator.Page)
+	}
+}
+
+func TestSetItemsCursorFollowsItemWhileFiltered(t *testing.T) {
+	m := New(idItems("apple", "banana", "apricot", "cherry"), NewDefaultDelegate(), 80, 40)
+	m = press(typeFilter(m, "ap"), "enter", "down")
+	want, _ := itemID(m.SelectedItem())
+	if want == "" {
+		t.Fatal("expected a match to be selected")
+	}
+
+	m.SetItems(idItems("cherry", "apricot", "banana", "apple"))
+	if got, _ := itemID(m.SelectedItem()); got != want {
+		t.Errorf("expected the cursor to stay on item %q, got %q", want, got)
+	}
+}
@@ -15,6 +15,19 @@ type KeyMap struct {
 	Filter      key.Binding
 	ClearFilter key.Binding
 
+	// Keybindings used to move between columns when the delegate lays items
+	// out in a grid.
+	CursorLeft  key.Binding
+	CursorRight key.Binding
+
+	// Keybindings used to expand and collapse items in tree mode.
+	Expand   key.Binding
+	Collapse key.Binding
+
+	// Keybindings used to select items when multi-select is enabled.
+	ToggleSelect    key.Binding
+	ToggleSelectAll key.Binding
+
 	// Keybindings used when setting a filter.
 	CancelWhileFiltering key.Binding
 	AcceptWhileFiltering key.Binding
@@ -67,6 +80,36 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("esc", "clear filter"),
 		),
 
+		// Grid layouts.
+		CursorLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "left"),
+		),
+		CursorRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "right"),
+		),
+
+		// Tree mode.
+		Expand: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "expand"),
+		),
+		Collapse: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "collapse"),
+		),
+
+		// Multi-select.
+		ToggleSelect: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select"),
+		),
+		ToggleSelectAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "select all"),
+		),
+
 		// Filtering.
 		CancelWhileFiltering: key.NewBinding(
 			key.WithKeys("esc"),
@@ -52,9 +52,86 @@ type ItemDelegate interface {
 	Update(msg tea.Msg, m *Model) tea.Cmd
 }
 
+// VariableHeightDelegate is an ItemDelegate whose items can differ in height,
+// such as items with wrapped titles or a varying number of description lines.
+// When the delegate implements this interface the list paginates by adding up
+// the heights of the items, fitting as many as it can on each page, instead
+// of assuming every item is Height lines tall.
+type VariableHeightDelegate interface {
+	ItemDelegate
+
+	// HeightForItem returns the height of the given item, where index is the
+	// item's index in VisibleItems. The model is passed along since an
+	// item's height will often depend on the width of the list.
+	HeightForItem(m Model, index int, item Item) int
+}
+
+// PageDelegate is an ItemDelegate which renders a whole page of items at once,
+// allowing for layouts such as grids. When the delegate implements this
+// interface the list calls RenderPage instead of Render.
+//
+// The list still owns pagination: each page holds Columns items per row, and
+// as many rows of Height lines (plus Spacing) as fit. When there's more than
+// one column the cursor moves up and down by row, and left and right within a
+// row. Items are always paginated as Height lines tall, even if the delegate
+// also implements VariableHeightDelegate, as delegates embedding
+// DefaultDelegate do.
+type PageDelegate interface {
+	ItemDelegate
+
+	// RenderPage renders the items on the current page. Indices holds the
+	// index of each item in VisibleItems; compare these with Index to find
+	// the selected item.
+	RenderPage(w io.Writer, m Model, items []Item, indices []int)
+
+	// Columns returns the number of items in each row for a list of the
+	// given width.
+	Columns(width int) int
+}
+
+// Rank describes a target matched by a FilterFunc.
+type Rank struct {
+	// The index of the target in the slice of targets passed to the
+	// FilterFunc.
+	Index int
+
+	// Indices of the runes in the target matched by the term. These are
+	// highlighted by the default delegate. May be empty.
+	MatchedIndexes []int
+
+	// How well the target matched the term. Higher is better. The scale is
+	// up to the FilterFunc. See Model.MatchScore.
+	Score int
+}
+
+// FilterFunc matches a filter term against the filter values of the items in
+// the list, returning a rank for each matching target in the order the
+// matches should be displayed.
+type FilterFunc func(term string, targets []string) []Rank
+
+// DefaultFilter uses the sahilm/fuzzy package to fuzzy match the term against
+// the targets. Matches are sorted by how well they match.
+func DefaultFilter(term string, targets []string) []Rank {
+	var ranks fuzzy.Matches = fuzzy.Find(term, targets)
+	sort.Stable(ranks)
+
+	result := make([]Rank, len(ranks))
+	for i, r := range ranks {
+		result[i] = Rank{
+			Index:          r.Index,
+			MatchedIndexes: r.MatchedIndexes,
+			Score:          r.Score,
+		}
+	}
+	return result
+}
+
 type filteredItem struct {
+	index   int   // index of the item in the list's full slice of items
 	item    Item  // item matched
 	matches []int // rune indices of matched items
+	matched bool  // whether the item matched, rather than being shown for context
+	score   int   // score of the match
 }
 
 type filteredItems []filteredItem
@@ -71,7 +148,9 @@ func (f filteredItems) items() []Item {
 // message should be routed to Update for processing.
 type FilterMatchesMsg []filteredItem
 
-type statusMessageTimeoutMsg struct{}
+type statusMessageTimeoutMsg struct {
+	id int
+}
 
 // FilterState describes the current filtering state on the model.
 type FilterState int
@@ -92,6 +171,43 @@ func (f FilterState) String() string {
 	}[f]
 }
 
+// PageCursorBehavior determines where the cursor is placed when moving
+// between pages.
+type PageCursorBehavior int
+
+// Available page cursor behaviors.
+const (
+	// PageCursorKeepRow keeps the cursor on the same row of the screen when
+	// changing pages. On a partial last page the cursor is moved to the last
+	// item if its row is empty.
+	PageCursorKeepRow PageCursorBehavior = iota
+
+	// PageCursorTop moves the cursor to the first item on the new page.
+	PageCursorTop
+)
+
+// ViewModel is a structured snapshot of what the list is currently
+// displaying. It's primarily intended for testing, so that assertions can be
+// made against the list's state rather than its rendered output.
+type ViewModel struct {
+	// Items are the items rendered on the current page, in display order.
+	Items []Item
+
+	// Indices are the indices of Items as they appear in VisibleItems.
+	Indices []int
+
+	// Selected is the index of the selected item as it appears in
+	// VisibleItems, or -1 if there's nothing to select.
+	Selected int
+
+	FilterState FilterState
+	FilterValue string
+
+	Page       int
+	PerPage    int
+	TotalPages int
+}
+
 // Model contains the state of this component.
 type Model struct {
 	showTitle        bool
@@ -107,6 +223,10 @@ type Model struct {
 	// Key mappings for navigating the list.
 	KeyMap KeyMap
 
+	// Where to place the cursor when moving between pages. By default the
+	// cursor stays on the same row.
+	PageCursorBehavior PageCursorBehavior
+
 	// Additional key mappings for the short and full help views. This allows
 	// you to add additional key mappings to the help menu without
 	// re-implementing the help component. Of course, you can also disable the
@@ -115,6 +235,11 @@ type Model struct {
 	AdditionalShortHelpKeys func() []key.Binding
 	AdditionalFullHelpKeys  func() []key.Binding
 
+	// Filter is used to match the filter term against items. By default
+	// this is DefaultFilter, which does fuzzy matching. It's never called
+	// with an empty term: an empty filter matches all items.
+	Filter FilterFunc
+
 	spinner     spinner.Model
 	showSpinner bool
 	width       int
@@ -129,8 +254,9 @@ type Model struct {
 	// 1 second.
 	StatusMessageLifetime time.Duration
 
-	statusMessage      string
-	statusMessageTimer *time.Timer
+	statusMessage    string
+	statusMessageErr bool
+	statusMessageID  int
 
 	// The master set of items we're working with.
 	items []Item
@@ -141,6 +267,38 @@ type Model struct {
 	filteredItems filteredItems
 
 	delegate ItemDelegate
+
+	// The index of the first item on each page, for delegates with variable
+	// height items. With fixed height items pages are all the same size and
+	// this is nil.
+	pageStarts []int
+
+	// Rendered items, keyed by the state that affects their rendering. The
+	// cache is nil when disabled.
+	renderCache map[renderCacheKey]string
+
+	// Tree mode. The tree is flattened into every node, depth first, and the
+	// indices of the nodes currently visible given the expansion state.
+	treeMode    bool
+	expanded    map[string]bool
+	treeNodes   []treeNode
+	treeVisible []int
+
+	// Multi-select. Selected items are keyed by their index in items or, in
+	// tree mode, treeNodes.
+	multiSelect bool
+	selection   map[int]struct{}
+}
+
+// renderCacheKey identifies a rendered item in the render cache. Anything
+// that isn't part of the key, such as the items themselves or the filter
+// matches, clears the cache when it changes.
+type renderCacheKey struct {
+	index       int
+	width       int
+	selected    bool
+	checked     bool
+	filterState FilterState
 }
 
 // New returns a new model with sensible defaults.
@@ -175,6 +333,7 @@ func New(items []Item, delegate ItemDelegate, width, height int) Model {
 		Title:                 "List",
 		FilterInput:           filterInput,
 		StatusMessageLifetime: time.Second,
+		Filter:                DefaultFilter,
 
 		width:     width,
 		height:    height,
@@ -281,24 +440,54 @@ func (m Model) Items() []Item {
 }
 
 // Set the items available in the list. This returns a command.
+//
+// If the items implement IdentifiableItem the cursor and the selection follow
+// the items they were on, matched by ID. Otherwise the cursor stays at the
+// same position and the selection is cleared.
 func (m *Model) SetItems(i []Item) tea.Cmd {
-	var cmd tea.Cmd
+	var (
+		cmd         tea.Cmd
+		cursorID, _ = itemID(m.SelectedItem())
+		selectedIDs = m.selectedIDs()
+	)
+
 	m.items = i
+	m.rebuildTree()
+	m.ClearSelection()
+	m.restoreSelection(selectedIDs)
 
 	if m.filterState != Unfiltered {
 		m.filteredItems = nil
-		cmd = filterItems(*m)
+		if cursorID != "" {
+			// Filter right away so the cursor can be found among the matches
+			m.filteredItems = filteredItems(filterItems(*m)().(FilterMatchesMsg))
+		} else {
+			cmd = filterItems(*m)
+		}
 	}
 
 	m.updatePagination()
+	if cursorID != "" {
+		m.selectID(cursorID)
+	}
 	m.updateKeybindings()
 	return cmd
 }
 
 // Select selects the given index of the list and goes to its respective page.
 func (m *Model) Select(index int) {
-	m.Paginator.Page = index / m.Paginator.PerPage
-	m.cursor = index % m.Paginator.PerPage
+	if m.pageStarts == nil {
+		m.Paginator.Page = index / m.Paginator.PerPage
+		m.cursor = index % m.Paginator.PerPage
+		return
+	}
+
+	page := sort.Search(len(m.pageStarts), func(i int) bool {
+		return m.pageStarts[i] > index
+	}) - 1
+	page = max(0, page)
+	m.Paginator.Page = page
+	m.cursor = max(0, index-m.pageStarts[page])
 }
 
 // ResetSelected resets the selected item to the first item in the first page of the list.
@@ -311,10 +500,32 @@ func (m *Model) ResetFilter() {
 	m.resetFiltering()
 }
 
+// ResetView returns the list to a clean state in one step: any filter, applied
+// or in progress, is cleared and the first item on the first page is
+// selected. Items, settings and the help view are left untouched.
+func (m *Model) ResetView() {
+	m.resetFiltering()
+
+	// resetFiltering is a no-op when unfiltered, so make sure no stray
+	// filter state lingers regardless.
+	m.FilterInput.Reset()
+	m.filteredItems = nil
+
+	m.Paginator.Page = 0
+	m.cursor = 0
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
 // Replace an item at the given index. This returns a command.
 func (m *Model) SetItem(index int, item Item) tea.Cmd {
 	var cmd tea.Cmd
 	m.items[index] = item
+	m.rebuildTree()
+	if m.treeMode {
+		// The item's children may have changed
+		m.ClearSelection()
+	}
 
 	if m.filterState != Unfiltered {
 		cmd = filterItems(*m)
@@ -328,7 +539,9 @@ func (m *Model) SetItem(index int, item Item) tea.Cmd {
 // item will be appended. This returns a command.
 func (m *Model) InsertItem(index int, item Item) tea.Cmd {
 	var cmd tea.Cmd
+	m.shiftSelection(min(max(0, index), len(m.items)), 1)
 	m.items = insertItemIntoSlice(m.items, item, index)
+	m.rebuildTree()
 
 	if m.filterState != Unfiltered {
 		cmd = filterItems(*m)
@@ -343,8 +556,20 @@ func (m *Model) InsertItem(index int, item Item) tea.Cmd {
 // this will be a no-op. O(n) complexity, which probably won't matter in the
 // case of a TUI.
 func (m *Model) RemoveItem(index int) {
+	if index < 0 || index >= len(m.items) {
+		return
+	}
 	m.items = removeItemFromSlice(m.items, index)
-	if m.filterState != Unfiltered {
+	m.shiftSelection(index, -1)
+	m.rebuildTree()
+	if m.filterState != Unfiltered && m.treeMode {
+		// Removing an item shifts the positions of every node after it in
+		// the tree, so re-run the filter.
+		m.filteredItems = filteredItems(filterItems(*m)().(FilterMatchesMsg))
+		if len(m.filteredItems) == 0 {
+			m.resetFiltering()
+		}
+	} else if m.filterState != Unfiltered {
 		m.filteredItems = removeFilterMatchFromSlice(m.filteredItems, index)
 		if len(m.filteredItems) == 0 {
 			m.resetFiltering()
@@ -356,17 +581,91 @@ func (m *Model) RemoveItem(index int) {
 // Set the item delegate.
 func (m *Model) SetDelegate(d ItemDelegate) {
 	m.delegate = d
+	m.ClearRenderCache()
 	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// SetRenderCacheEnabled enables or disables caching of rendered items. When
+// enabled, an item is only re-rendered by the delegate when something that
+// affects it changes, such as the item itself, its selection state, the
+// filter or the list's width. This is useful for delegates that are expensive
+// to render.
+//
+// Because the cache can't know about state outside of the list, delegates
+// which render differently based on such state should either not use the
+// cache or call ClearRenderCache when that state changes.
+func (m *Model) SetRenderCacheEnabled(v bool) {
+	if !v {
+		m.renderCache = nil
+		return
+	}
+	if m.renderCache == nil {
+		m.renderCache = make(map[renderCacheKey]string)
+	}
+}
+
+// RenderCacheEnabled returns whether or not rendered items are cached.
+func (m Model) RenderCacheEnabled() bool {
+	return m.renderCache != nil
+}
+
+// ClearRenderCache discards all cached item renderings, if caching is
+// enabled.
+func (m *Model) ClearRenderCache() {
+	if m.renderCache != nil {
+		m.renderCache = make(map[renderCacheKey]string)
+	}
 }
 
-// VisibleItems returns the total items available to be shown.
+// VisibleItems returns exactly the items the list renders, across all pages,
+// in the order they're rendered. When unfiltered these are the same as Items.
+// While a filter is being set or is applied these are the items matching the
+// filter, ranked by how well they match; while the filter is empty that's all
+// items in their original order.
+//
+// Note that filtering runs asynchronously, so after the filter changes the
+// visible items will reflect the new filter once the resulting
+// FilterMatchesMsg has been processed by Update.
+//
+// In tree mode these are the items in the visible portion of the tree, in
+// tree order. While filtering in tree mode, ancestors of matching items are
+// included so the structure of the tree is preserved.
 func (m Model) VisibleItems() []Item {
 	if m.filterState != Unfiltered {
 		return m.filteredItems.items()
 	}
+	if m.treeMode {
+		return m.treeItems()
+	}
 	return m.items
 }
 
+// VisibleItemIndices returns, for each item in VisibleItems, its index in the
+// full slice of items returned by Items. This is useful for performing bulk
+// actions, like selecting all items matching the filter.
+func (m Model) VisibleItemIndices() []int {
+	if m.filterState != Unfiltered {
+		indices := make([]int, len(m.filteredItems))
+		for i, f := range m.filteredItems {
+			indices[i] = f.index
+		}
+		return indices
+	}
+
+	if m.treeMode {
+		indices := make([]int, len(m.treeVisible))
+		copy(indices, m.treeVisible)
+		return indices
+	}
+
+	indices := make([]int, len(m.items))
+	for i := range m.items {
+		indices[i] = i
+	}
+	return indices
+}
+
 // SelectedItems returns the current selected item in the list.
 func (m Model) SelectedItem() Item {
 	i := m.Index()
@@ -390,9 +689,64 @@ func (m Model) MatchesForItem(index int) []int {
 	return m.filteredItems[index].matches
 }
 
+// IsFiltered returns whether or not the item at the given index of
+// VisibleItems was matched by the current filter. It's false when no filter
+// is applied and, in tree mode, for items only shown because one of their
+// descendants matched.
+func (m Model) IsFiltered(index int) bool {
+	if m.filterState == Unfiltered || index < 0 || index >= len(m.filteredItems) {
+		return false
+	}
+	return m.filteredItems[index].matched
+}
+
+// MatchScore returns the score of the current filter's match against the item
+// at the given index of VisibleItems, as reported by the FilterFunc. Higher is
+// better. It's 0 for items the filter didn't match. See IsFiltered.
+func (m Model) MatchScore(index int) int {
+	if !m.IsFiltered(index) {
+		return 0
+	}
+	return m.filteredItems[index].score
+}
+
+// ViewModel returns a snapshot of the list's current display state. See type
+// ViewModel for details.
+func (m Model) ViewModel() ViewModel {
+	items := m.VisibleItems()
+
+	vm := ViewModel{
+		Selected:    -1,
+		FilterState: m.filterState,
+		FilterValue: m.FilterValue(),
+		Page:        m.Paginator.Page,
+		PerPage:     m.Paginator.PerPage,
+		TotalPages:  m.Paginator.TotalPages,
+	}
+
+	if len(items) == 0 {
+		return vm
+	}
+
+	start, end := m.pageBounds(len(items))
+	for i := start; i < end; i++ {
+		vm.Items = append(vm.Items, items[i])
+		vm.Indices = append(vm.Indices, i)
+	}
+
+	if i := m.Index(); i >= 0 && i < len(items) {
+		vm.Selected = i
+	}
+
+	return vm
+}
+
 // Index returns the index of the currently selected item as it appears in the
 // entire slice of items.
 func (m Model) Index() int {
+	if m.pageStarts != nil && m.Paginator.Page < len(m.pageStarts) {
+		return m.pageStarts[m.Paginator.Page] + m.cursor
+	}
 	return m.Paginator.Page*m.Paginator.PerPage + m.cursor
 }
 
@@ -404,6 +758,13 @@ func (m Model) Cursor() int {
 // CursorUp moves the cursor up. This can also move the state to the previous
 // page.
 func (m *Model) CursorUp() {
+	if cols := m.columns(); cols > 1 {
+		if i := m.Index() - cols; i >= 0 {
+			m.Select(i)
+		}
+		return
+	}
+
 	m.cursor--
 
 	// If we're at the start, stop
@@ -419,13 +780,25 @@ func (m *Model) CursorUp() {
 
 	// Go to the previous page
 	m.Paginator.PrevPage()
-	m.cursor = m.Paginator.ItemsOnPage(len(m.VisibleItems())) - 1
+	m.cursor = m.itemsOnPage(len(m.VisibleItems())) - 1
 }
 
 // CursorDown moves the cursor down. This can also advance the state to the
 // next page.
 func (m *Model) CursorDown() {
-	itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
+	if cols := m.columns(); cols > 1 {
+		var (
+			numItems = len(m.VisibleItems())
+			i        = m.Index()
+		)
+		// Move to the last item if the row below is shorter than this one
+		if i/cols < (numItems-1)/cols {
+			m.Select(min(i+cols, numItems-1))
+		}
+		return
+	}
+
+	itemsOnPage := m.itemsOnPage(len(m.VisibleItems()))
 
 	m.cursor++
 
@@ -452,14 +825,66 @@ func (m *Model) CursorDown() {
 	m.cursor = itemsOnPage - 1
 }
 
-// PrevPage moves to the previous page, if available.
-func (m Model) PrevPage() {
+// CursorLeft moves the cursor to the previous item in the row when the delegate
+// lays items out in a grid. See PageDelegate.
+func (m *Model) CursorLeft() {
+	cols := m.columns()
+	if i := m.Index(); cols > 1 && i%cols > 0 {
+		m.Select(i - 1)
+	}
+}
+
+// CursorRight moves the cursor to the next item in the row when the delegate
+// lays items out in a grid. See PageDelegate.
+func (m *Model) CursorRight() {
+	cols := m.columns()
+	if i := m.Index(); cols > 1 && i%cols < cols-1 && i+1 < len(m.VisibleItems()) {
+		m.Select(i + 1)
+	}
+}
+
+// columns returns the number of items in each row.
+func (m Model) columns() int {
+	d, ok := m.delegate.(PageDelegate)
+	if !ok || m.pageStarts != nil {
+		return 1
+	}
+	return max(1, d.Columns(m.width))
+}
+
+// PrevPage moves to the previous page, if available. The cursor is placed
+// according to PageCursorBehavior.
+func (m *Model) PrevPage() {
+	page := m.Paginator.Page
 	m.Paginator.PrevPage()
+	if m.Paginator.Page != page {
+		m.placePageCursor()
+	}
 }
 
-// NextPage moves to the next page, if available.
-func (m Model) NextPage() {
+// NextPage moves to the next page, if available. The cursor is placed
+// according to PageCursorBehavior.
+func (m *Model) NextPage() {
+	page := m.Paginator.Page
 	m.Paginator.NextPage()
+	if m.Paginator.Page != page {
+		m.placePageCursor()
+	}
+}
+
+// placePageCursor places the cursor after a page change according to
+// PageCursorBehavior.
+func (m *Model) placePageCursor() {
+	if m.PageCursorBehavior == PageCursorTop {
+		m.cursor = 0
+		return
+	}
+
+	// Keep the row, but clamp to the items on a partial page
+	itemsOnPage := m.itemsOnPage(len(m.VisibleItems()))
+	if m.cursor > itemsOnPage-1 {
+		m.cursor = max(0, itemsOnPage-1)
+	}
 }
 
 // FilterState returns the current filter state.
@@ -528,18 +953,34 @@ func (m *Model) DisableQuitKeybindings() {
 // NewStatusMessage sets a new status message, which will show for a limited
 // amount of time. Note that this also returns a command.
 func (m *Model) NewStatusMessage(s string) tea.Cmd {
-	m.statusMessage = s
-	if m.statusMessageTimer != nil {
-		m.statusMessageTimer.Stop()
-	}
+	return m.newStatusMessage(s, m.StatusMessageLifetime, false)
+}
 
-	m.statusMessageTimer = time.NewTimer(m.StatusMessageLifetime)
+// NewStatusMessageWithDuration sets a new status message which will stay
+// visible for the given duration rather than StatusMessageLifetime.
+func (m *Model) NewStatusMessageWithDuration(s string, d time.Duration) tea.Cmd {
+	return m.newStatusMessage(s, d, false)
+}
+
+// NewErrorStatusMessage sets a new status message styled as an error, such as
+// when an action has failed. It's otherwise the same as NewStatusMessage.
+func (m *Model) NewErrorStatusMessage(s string) tea.Cmd {
+	return m.newStatusMessage(s, m.StatusMessageLifetime, true)
+}
+
+// newStatusMessage shows a status message. A new message replaces the current
+// one, along with its timeout, so the new message is shown for its full
+// lifetime.
+func (m *Model) newStatusMessage(s string, d time.Duration, isErr bool) tea.Cmd {
+	m.statusMessage = s
+	m.statusMessageErr = isErr
+	m.statusMessageID++
 
 	// Wait for timeout
-	return func() tea.Msg {
-		<-m.statusMessageTimer.C
-		return statusMessageTimeoutMsg{}
-	}
+	id := m.statusMessageID
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return statusMessageTimeoutMsg{id: id}
+	})
 }
 
 // SetSize sets the width and height of this component.
@@ -560,11 +1001,16 @@ func (m *Model) SetHeight(v int) {
 func (m *Model) setSize(width, height int) {
 	promptWidth := lipgloss.Width(m.Styles.Title.Render(m.FilterInput.Prompt))
 
+	if width != m.width {
+		m.ClearRenderCache()
+	}
+
 	m.width = width
 	m.height = height
 	m.Help.Width = width
 	m.FilterInput.Width = width - promptWidth - lipgloss.Width(m.spinnerView())
 	m.updatePagination()
+	m.updateKeybindings()
 }
 
 func (m *Model) resetFiltering() {
@@ -575,15 +1021,28 @@ func (m *Model) resetFiltering() {
 	m.filterState = Unfiltered
 	m.FilterInput.Reset()
 	m.filteredItems = nil
+	m.ClearRenderCache()
 	m.updatePagination()
 	m.updateKeybindings()
 }
 
 func (m Model) itemsAsFilterItems() filteredItems {
+	if m.treeMode {
+		fi := make([]filteredItem, len(m.treeVisible))
+		for i, n := range m.treeVisible {
+			fi[i] = filteredItem{
+				index: n,
+				item:  m.treeNodes[n].item,
+			}
+		}
+		return filteredItems(fi)
+	}
+
 	fi := make([]filteredItem, len(m.items))
 	for i, item := range m.items {
 		fi[i] = filteredItem{
-			item: item,
+			index: i,
+			item:  item,
 		}
 	}
 	return filteredItems(fi)
@@ -602,6 +1061,12 @@ func (m *Model) updateKeybindings() {
 		m.KeyMap.GoToEnd.SetEnabled(false)
 		m.KeyMap.Filter.SetEnabled(false)
 		m.KeyMap.ClearFilter.SetEnabled(false)
+		m.KeyMap.CursorLeft.SetEnabled(false)
+		m.KeyMap.CursorRight.SetEnabled(false)
+		m.KeyMap.Expand.SetEnabled(false)
+		m.KeyMap.Collapse.SetEnabled(false)
+		m.KeyMap.ToggleSelect.SetEnabled(false)
+		m.KeyMap.ToggleSelectAll.SetEnabled(false)
 		m.KeyMap.CancelWhileFiltering.SetEnabled(true)
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
 		m.KeyMap.Quit.SetEnabled(false)
@@ -620,6 +1085,17 @@ func (m *Model) updateKeybindings() {
 		m.KeyMap.GoToStart.SetEnabled(hasItems)
 		m.KeyMap.GoToEnd.SetEnabled(hasItems)
 
+		isGrid := hasItems && m.columns() > 1
+		m.KeyMap.CursorLeft.SetEnabled(isGrid)
+		m.KeyMap.CursorRight.SetEnabled(isGrid)
+
+		canExpand := m.treeMode && hasItems && m.filterState == Unfiltered
+		m.KeyMap.Expand.SetEnabled(canExpand)
+		m.KeyMap.Collapse.SetEnabled(canExpand)
+
+		m.KeyMap.ToggleSelect.SetEnabled(m.multiSelect && hasItems)
+		m.KeyMap.ToggleSelectAll.SetEnabled(m.multiSelect && hasItems)
+
 		m.KeyMap.Filter.SetEnabled(m.filteringEnabled && hasItems)
 		m.KeyMap.ClearFilter.SetEnabled(m.filterState == FilterApplied)
 		m.KeyMap.CancelWhileFiltering.SetEnabled(false)
@@ -655,17 +1131,22 @@ func (m *Model) updatePagination() {
 		availHeight -= lipgloss.Height(m.helpView())
 	}
 
-	m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
-
-	if pages := len(m.VisibleItems()); pages < 1 {
-		m.Paginator.SetTotalPages(1)
+	if d, ok := m.variableHeightDelegate(); ok {
+		m.paginateVariableHeight(d, availHeight)
 	} else {
-		m.Paginator.SetTotalPages(pages)
+		m.pageStarts = nil
+		m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
+		m.Paginator.PerPage *= m.columns()
+
+		if pages := len(m.VisibleItems()); pages < 1 {
+			m.Paginator.SetTotalPages(1)
+		} else {
+			m.Paginator.SetTotalPages(pages)
+		}
 	}
 
 	// Restore index
-	m.Paginator.Page = index / m.Paginator.PerPage
-	m.cursor = index % m.Paginator.PerPage
+	m.Select(index)
 
 	// Make sure the page stays in bounds
 	if m.Paginator.Page >= m.Paginator.TotalPages-1 {
@@ -673,11 +1154,88 @@ func (m *Model) updatePagination() {
 	}
 }
 
+// variableHeightDelegate returns the delegate if its items should be
+// paginated by their individual heights. Grid layouts take precedence, so
+// page delegates are paginated as fixed height even if they implement
+// VariableHeightDelegate, as are default delegates which don't wrap.
+func (m Model) variableHeightDelegate() (VariableHeightDelegate, bool) {
+	if _, ok := m.delegate.(PageDelegate); ok {
+		return nil, false
+	}
+	if d, ok := m.delegate.(interface{ wraps() bool }); ok && !d.wraps() {
+		// Default delegates which don't wrap are always fixed height
+		return nil, false
+	}
+	d, ok := m.delegate.(VariableHeightDelegate)
+	return d, ok
+}
+
+// paginateVariableHeight splits the visible items into pages, fitting as many
+// items on each page as the available height allows. As with fixed height
+// items, each item takes up its height plus the spacing after it, so items of
+// equal height are paginated exactly as they would be otherwise. Every page
+// has at least one item, even if it doesn't fit.
+func (m *Model) paginateVariableHeight(d VariableHeightDelegate, availHeight int) {
+	items := m.VisibleItems()
+	m.pageStarts = []int{0}
+
+	var (
+		used    int
+		perPage int
+		onPage  int
+	)
+	for i, item := range items {
+		h := max(0, d.HeightForItem(*m, i, item)) + d.Spacing()
+		if onPage > 0 && used+h > availHeight {
+			m.pageStarts = append(m.pageStarts, i)
+			used, onPage = 0, 0
+		}
+		used += h
+		onPage++
+		perPage = max(perPage, onPage)
+	}
+
+	// PerPage isn't used to paginate variable height items, but keep it
+	// meaningful for anyone reading it.
+	m.Paginator.PerPage = max(1, perPage)
+	m.Paginator.TotalPages = len(m.pageStarts)
+}
+
+// pageBounds returns the bounds of the current page in a slice of the given
+// length.
+func (m Model) pageBounds(length int) (start, end int) {
+	if m.pageStarts == nil {
+		return m.Paginator.GetSliceBounds(length)
+	}
+
+	page := m.Paginator.Page
+	if page < 0 || page >= len(m.pageStarts) {
+		return 0, 0
+	}
+	start = min(m.pageStarts[page], length)
+	end = length
+	if page+1 < len(m.pageStarts) {
+		end = min(m.pageStarts[page+1], length)
+	}
+	return start, end
+}
+
+// itemsOnPage returns the number of items on the current page given the total
+// number of items.
+func (m Model) itemsOnPage(totalItems int) int {
+	if m.pageStarts == nil {
+		return m.Paginator.ItemsOnPage(totalItems)
+	}
+	start, end := m.pageBounds(totalItems)
+	return end - start
+}
+
 func (m *Model) hideStatusMessage() {
 	m.statusMessage = ""
-	if m.statusMessageTimer != nil {
-		m.statusMessageTimer.Stop()
-	}
+	m.statusMessageErr = false
+
+	// Invalidate the pending timeout, if any
+	m.statusMessageID++
 }
 
 // Update is the Bubble Tea update loop.
@@ -692,6 +1250,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case FilterMatchesMsg:
 		m.filteredItems = filteredItems(msg)
+		m.ClearRenderCache()
+		if m.pageStarts != nil {
+			// Page sizes depend on which items are visible
+			m.updatePagination()
+		}
 		return m, nil
 
 	case spinner.TickMsg:
@@ -702,7 +1265,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 	case statusMessageTimeoutMsg:
-		m.hideStatusMessage()
+		// Ignore timeouts for messages that have since been replaced
+		if msg.id == m.statusMessageID {
+			m.hideStatusMessage()
+		}
 	}
 
 	if m.filterState == Filtering {
@@ -736,11 +1302,34 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, m.KeyMap.CursorDown):
 			m.CursorDown()
 
+		// Note: we match expand and collapse before paging because, by
+		// default, they share keys. They're only enabled in tree mode.
+		case key.Matches(msg, m.KeyMap.Expand):
+			m.Expand()
+
+		case key.Matches(msg, m.KeyMap.Collapse):
+			m.Collapse()
+
+		// Likewise, moving within a row is only enabled for grid layouts.
+		case key.Matches(msg, m.KeyMap.CursorLeft):
+			m.CursorLeft()
+
+		case key.Matches(msg, m.KeyMap.CursorRight):
+			m.CursorRight()
+
+		case key.Matches(msg, m.KeyMap.ToggleSelect):
+			if i, ok := m.itemIndex(m.Index()); ok {
+				m.ToggleSelect(i)
+			}
+
+		case key.Matches(msg, m.KeyMap.ToggleSelectAll):
+			m.toggleSelectAll()
+
 		case key.Matches(msg, m.KeyMap.PrevPage):
-			m.Paginator.PrevPage()
+			m.PrevPage()
 
 		case key.Matches(msg, m.KeyMap.NextPage):
-			m.Paginator.NextPage()
+			m.NextPage()
 
 		case key.Matches(msg, m.KeyMap.GoToStart):
 			m.Paginator.Page = 0
@@ -748,18 +1337,21 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 		case key.Matches(msg, m.KeyMap.GoToEnd):
 			m.Paginator.Page = m.Paginator.TotalPages - 1
-			m.cursor = m.Paginator.ItemsOnPage(numItems) - 1
+			m.cursor = m.itemsOnPage(numItems) - 1
 
 		case key.Matches(msg, m.KeyMap.Filter):
 			m.hideStatusMessage()
 			if m.FilterInput.Value() == "" {
 				// Populate filter with all items only if the filter is empty.
 				m.filteredItems = m.itemsAsFilterItems()
+				m.ClearRenderCache()
 			}
 			m.Paginator.Page = 0
 			m.cursor = 0
 			m.filterState = Filtering
-			m.FilterInput.CursorEnd()
+			// Leave the input's cursor where the user last left it so that
+			// refining a long filter term picks up right where they were
+			// editing. An empty filter will have the cursor at the start.
 			m.FilterInput.Focus()
 			m.updateKeybindings()
 			return textinput.Blink
@@ -776,7 +1368,7 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 	cmds = append(cmds, cmd)
 
 	// Keep the index in bounds when paginating
-	itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
+	itemsOnPage := m.itemsOnPage(len(m.VisibleItems()))
 	if m.cursor > itemsOnPage-1 {
 		m.cursor = max(0, itemsOnPage-1)
 	}
@@ -821,7 +1413,12 @@ func (m *Model) handleFiltering(msg tea.Msg) tea.Cmd {
 		}
 	}
 
-	// Update the filter text input component
+	// Update the filter text input component. Note that we route all keys
+	// not handled above to the text input, so its full set of editing
+	// bindings, such as alt+backspace to delete a word and alt+left/right to
+	// move by word, are available when editing mid-string. Filtering is
+	// re-run asynchronously and never touches the input itself, so the
+	// cursor position is preserved.
 	newFilterInputModel, inputCmd := m.FilterInput.Update(msg)
 	filterChanged := m.FilterInput.Value() != newFilterInputModel.Value()
 	m.FilterInput = newFilterInputModel
@@ -829,6 +1426,7 @@ func (m *Model) handleFiltering(msg tea.Msg) tea.Cmd {
 
 	// If the filtering input has changed, request updated filtering
 	if filterChanged {
+		m.ClearRenderCache()
 		cmds = append(cmds, filterItems(*m))
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
 	}
@@ -880,10 +1478,16 @@ func (m Model) FullHelp() [][]key.Binding {
 	kb := [][]key.Binding{{
 		m.KeyMap.CursorUp,
 		m.KeyMap.CursorDown,
+		m.KeyMap.CursorLeft,
+		m.KeyMap.CursorRight,
 		m.KeyMap.NextPage,
 		m.KeyMap.PrevPage,
 		m.KeyMap.GoToStart,
 		m.KeyMap.GoToEnd,
+		m.KeyMap.Expand,
+		m.KeyMap.Collapse,
+		m.KeyMap.ToggleSelect,
+		m.KeyMap.ToggleSelectAll,
 	}}
 
 	filtering := m.filterState == Filtering
@@ -987,7 +1591,14 @@ func (m Model) titleView() string {
 
 		// Status message
 		if m.filterState != Filtering {
-			view += "  " + m.statusMessage
+			view += "  "
+			if m.statusMessage != "" {
+				style := m.Styles.StatusMessage
+				if m.statusMessageErr {
+					style = m.Styles.StatusMessageError
+				}
+				view += style.Render(m.statusMessage)
+			}
 			view = truncate.StringWithTail(view, uint(m.width-spinnerWidth), ellipsis)
 		}
 	}
@@ -1009,6 +1620,9 @@ func (m Model) statusView() string {
 	var status string
 
 	totalItems := len(m.items)
+	if m.treeMode {
+		totalItems = len(m.treeNodes)
+	}
 	visibleItems := len(m.VisibleItems())
 
 	plural := ""
@@ -1040,7 +1654,7 @@ func (m Model) statusView() string {
 	}
 
 	numFiltered := totalItems - visibleItems
-	if numFiltered > 0 {
+	if numFiltered > 0 && m.filterState != Unfiltered {
 		status += m.Styles.DividerDot.String()
 		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d filtered", numFiltered))
 	}
@@ -1083,12 +1697,24 @@ func (m Model) populatedView() string {
 		m.Styles.NoItems.Render("No items found.")
 	}
 
+	if d, ok := m.delegate.(PageDelegate); ok && len(items) > 0 {
+		start, end := m.pageBounds(len(items))
+		indices := make([]int, end-start)
+		for i := range indices {
+			indices[i] = start + i
+		}
+		d.RenderPage(&b, m, items[start:end], indices)
+
+		// The page is padded by View
+		return b.String()
+	}
+
 	if len(items) > 0 {
-		start, end := m.Paginator.GetSliceBounds(len(items))
+		start, end := m.pageBounds(len(items))
 		docs := items[start:end]
 
 		for i, item := range docs {
-			m.delegate.Render(&b, m, i+start, item)
+			m.renderItem(&b, i+start, item)
 			if i != len(docs)-1 {
 				fmt.Fprint(&b, strings.Repeat("\n", m.delegate.Spacing()+1))
 			}
@@ -1097,9 +1723,9 @@ func (m Model) populatedView() string {
 
 	// If there aren't enough items to fill up this page (always the last page)
 	// then we need to add some newlines to fill up the space where items would
-	// have been.
-	itemsOnPage := m.Paginator.ItemsOnPage(len(items))
-	if itemsOnPage < m.Paginator.PerPage {
+	// have been. Pages of variable height items are padded by View instead.
+	itemsOnPage := m.itemsOnPage(len(items))
+	if m.pageStarts == nil && itemsOnPage < m.Paginator.PerPage {
 		n := (m.Paginator.PerPage - itemsOnPage) * (m.delegate.Height() + m.delegate.Spacing())
 		if len(items) == 0 {
 			n -= m.delegate.Height() - 1
@@ -1110,6 +1736,32 @@ func (m Model) populatedView() string {
 	return b.String()
 }
 
+// renderItem renders an item with the delegate, consulting the render cache if
+// it's enabled.
+func (m Model) renderItem(w io.Writer, index int, item Item) {
+	if m.renderCache == nil {
+		m.delegate.Render(w, m, index, item)
+		return
+	}
+
+	k := renderCacheKey{
+		index:       index,
+		width:       m.width,
+		selected:    index == m.Index(),
+		checked:     m.isVisibleItemSelected(index),
+		filterState: m.filterState,
+	}
+	if v, ok := m.renderCache[k]; ok {
+		fmt.Fprint(w, v)
+		return
+	}
+
+	var b strings.Builder
+	m.delegate.Render(&b, m, index, item)
+	m.renderCache[k] = b.String()
+	fmt.Fprint(w, b.String())
+}
+
 func (m Model) helpView() string {
 	return m.Styles.HelpStyle.Render(m.Help.View(m))
 }
@@ -1124,6 +1776,12 @@ func filterItems(m Model) tea.Cmd {
 			return FilterMatchesMsg(m.itemsAsFilterItems()) // return nothing
 		}
 
+		filter := m.filterFunc()
+
+		if m.treeMode {
+			return FilterMatchesMsg(filterTree(filter, m.FilterInput.Value(), m.treeNodes))
+		}
+
 		targets := []string{}
 		items := m.items
 
@@ -1131,14 +1789,16 @@ func filterItems(m Model) tea.Cmd {
 			targets = append(targets, t.FilterValue())
 		}
 
-		var ranks fuzzy.Matches = fuzzy.Find(m.FilterInput.Value(), targets)
-		sort.Stable(ranks)
+		ranks := filter(m.FilterInput.Value(), targets)
 
 		filterMatches := []filteredItem{}
 		for _, r := range ranks {
 			filterMatches = append(filterMatches, filteredItem{
+				index:   r.Index,
 				item:    items[r.Index],
 				matches: r.MatchedIndexes,
+				matched: true,
+				score:   r.Score,
 			})
 		}
 
@@ -1146,6 +1806,14 @@ func filterItems(m Model) tea.Cmd {
 	}
 }
 
+// filterFunc returns the function used to filter items.
+func (m Model) filterFunc() FilterFunc {
+	if m.Filter == nil {
+		return DefaultFilter
+	}
+	return m.Filter
+}
+
 func insertItemIntoSlice(items []Item, item Item, index int) []Item {
 	if items == nil {
 		return []Item{item}
@@ -1172,13 +1840,24 @@ func removeItemFromSlice(i []Item, index int) []Item {
 	return i[:len(i)-1]
 }
 
+// Remove the filter match for the item at the given index in the full slice
+// of items, shifting the indices of subsequent items to account for the
+// removal. This runs in O(n).
 func removeFilterMatchFromSlice(i []filteredItem, index int) []filteredItem {
-	if index >= len(i) {
-		return i // noop
+	out := i[:0]
+	for _, f := range i {
+		if f.index == index {
+			continue
+		}
+		if f.index > index {
+			f.index--
+		}
+		out = append(out, f)
 	}
-	copy(i[index:], i[index+1:])
-	i[len(i)-1] = filteredItem{}
-	return i[:len(i)-1]
+	for j := len(out); j < len(i); j++ {
+		i[j] = filteredItem{}
+	}
+	return out
 }
 
 func countEnabledBindings(groups [][]key.Binding) (agg int) {
@@ -1192,6 +1871,13 @@ func countEnabledBindings(groups [][]key.Binding) (agg int) {
 	return agg
 }
 
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,215 @@
+package list
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type testItem struct {
+	title, desc string
+}
+
+func (i testItem) Title() string       { return i.title }
+func (i testItem) Description() string { return i.desc }
+func (i testItem) FilterValue() string { return i.title }
+
+// testItems returns n items titled "item 0", "item 1" and so on.
+func testItems(n int) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		items[i] = testItem{title: fmt.Sprintf("item %d", i)}
+	}
+	return items
+}
+
+// keyMsg returns the key message for the given key, which is either the name
+// of a special key, such as "enter", or runes.
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// press sends the given keys to the list.
+func press(m Model, keys ...string) Model {
+	for _, k := range keys {
+		m, _ = m.Update(keyMsg(k))
+	}
+	return m
+}
+
+// typeFilter starts filtering and types the given term, applying the matches
+// as the list would once filtering finishes in the background.
+func typeFilter(m Model, term string) Model {
+	m = press(m, "/")
+	for _, r := range term {
+		m = press(m, string(r))
+	}
+	m, _ = m.Update(filterItems(m)())
+	return m
+}
+
+// gridDelegate lays items out in three columns, customizing DefaultDelegate
+// by embedding it.
+type gridDelegate struct {
+	DefaultDelegate
+}
+
+func (d gridDelegate) Columns(int) int { return 3 }
+
+func (d gridDelegate) RenderPage(w io.Writer, m Model, items []Item, indices []int) {
+	for i, item := range items {
+		d.Render(w, m, indices[i], item)
+	}
+}
+
+// plainGridDelegate lays items out in three columns without embedding
+// DefaultDelegate.
+type plainGridDelegate struct {
+	height, spacing int
+}
+
+func (d plainGridDelegate) Height() int                        { return d.height }
+func (d plainGridDelegate) Spacing() int                       { return d.spacing }
+func (d plainGridDelegate) Update(tea.Msg, *Model) tea.Cmd     { return nil }
+func (d plainGridDelegate) Render(io.Writer, Model, int, Item) {}
+func (d plainGridDelegate) Columns(int) int                    { return 3 }
+
+func (d plainGridDelegate) RenderPage(io.Writer, Model, []Item, []int) {}
+
+func TestGridDelegateEmbeddingDefaultDelegate(t *testing.T) {
+	embedded := gridDelegate{NewDefaultDelegate()}
+	plain := plainGridDelegate{height: embedded.Height(), spacing: embedded.Spacing()}
+
+	m := New(testItems(20), embedded, 80, 30)
+	want := New(testItems(20), plain, 80, 30)
+
+	if m.pageStarts != nil {
+		t.Errorf("expected fixed height pagination, got page starts %v", m.pageStarts)
+	}
+	if m.Paginator.PerPage != want.Paginator.PerPage {
+		t.Errorf("expected %d items per page, got %d", want.Paginator.PerPage, m.Paginator.PerPage)
+	}
+	if m.Paginator.TotalPages != want.Paginator.TotalPages {
+		t.Errorf("expected %d pages, got %d", want.Paginator.TotalPages, m.Paginator.TotalPages)
+	}
+
+	// Left and right move within the row rather than between pages
+	m.CursorRight()
+	if m.Index() != 1 || m.Paginator.Page != 0 {
+		t.Errorf("expected to move right to item 1 on page 0, got item %d on page %d", m.Index(), m.Paginator.Page)
+	}
+	m.CursorLeft()
+	if m.Index() != 0 || m.Paginator.Page != 0 {
+		t.Errorf("expected to move left to item 0 on page 0, got item %d on page %d", m.Index(), m.Paginator.Page)
+	}
+}
+
+func TestResetView(t *testing.T) {
+	tests := []struct {
+		name  string
+		state FilterState // filter state after setup
+		setup func(Model) Model
+	}{
+		{"filtered", FilterApplied, func(m Model) Model {
+			return press(typeFilter(m, "item 1"), "enter", "down")
+		}},
+		{"filtering", Filtering, func(m Model) Model {
+			return typeFilter(m, "item 1")
+		}},
+		{"later page", Unfiltered, func(m Model) Model {
+			m.Select(30)
+			return m
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := testItems(50)
+			m := tt.setup(New(items, NewDefaultDelegate(), 80, 20))
+			if m.FilterState() != tt.state {
+				t.Fatalf("expected filter state %s after setup, got %s", tt.state, m.FilterState())
+			}
+			if m.FilterState() == Unfiltered && m.Paginator.Page == 0 {
+				t.Fatal("expected the setup to move to a later page")
+			}
+
+			m.ResetView()
+
+			if m.FilterState() != Unfiltered {
+				t.Errorf("expected filter state %s, got %s", Unfiltered, m.FilterState())
+			}
+			if m.FilterValue() != "" {
+				t.Errorf("expected the filter to be cleared, got %q", m.FilterValue())
+			}
+			if m.Index() != 0 || m.Paginator.Page != 0 {
+				t.Errorf("expected item 0 on page 0 to be selected, got item %d on page %d", m.Index(), m.Paginator.Page)
+			}
+			if len(m.Items()) != len(items) || len(m.VisibleItems()) != len(items) {
+				t.Errorf("expected %d items, got %d with %d visible", len(items), len(m.Items()), len(m.VisibleItems()))
+			}
+			for i, item := range m.Items() {
+				if item != items[i] {
+					t.Errorf("expected item %d to be %v, got %v", i, items[i], item)
+				}
+			}
+		})
+	}
+}
+
+// heightDelegate gives each item the height at its index in heights.
+type heightDelegate struct {
+	heights []int
+}
+
+func (d heightDelegate) Height() int                        { return 1 }
+func (d heightDelegate) Spacing() int                       { return 1 }
+func (d heightDelegate) Update(tea.Msg, *Model) tea.Cmd     { return nil }
+func (d heightDelegate) Render(io.Writer, Model, int, Item) {}
+
+func (d heightDelegate) HeightForItem(_ Model, index int, _ Item) int {
+	return d.heights[index]
+}
+
+func TestVariableHeightPagination(t *testing.T) {
+	// With one line of spacing after each, items take up one more line than
+	// their height. The last item doesn't fit on a page at all, so it gets
+	// a page to itself.
+	d := heightDelegate{heights: []int{1, 3, 2, 4, 1, 1, 5, 2, 9}}
+	m := New(testItems(len(d.heights)), d, 80, 8)
+	m.SetShowTitle(false)
+	m.SetShowFilter(false)
+	m.SetShowStatusBar(false)
+	m.SetShowPagination(false)
+	m.SetShowHelp(false)
+
+	want := []int{0, 2, 4, 6, 7, 8}
+	if !reflect.DeepEqual(m.pageStarts, want) {
+		t.Fatalf("expected page starts %v, got %v", want, m.pageStarts)
+	}
+	if m.Paginator.TotalPages != len(want) {
+		t.Errorf("expected %d pages, got %d", len(want), m.Paginator.TotalPages)
+	}
+
+	m.Select(5)
+	if m.Paginator.Page != 2 || m.cursor != 1 || m.Index() != 5 {
+		t.Errorf("expected item 5 to be item 1 on page 2, got item %d on page %d (index %d)", m.cursor, m.Paginator.Page, m.Index())
+	}
+
+	m.Paginator.Page = 3
+	if start, end := m.pageBounds(len(d.heights)); start != 6 || end != 7 {
+		t.Errorf("expected page 3 to hold items 6 to 7, got %d to %d", start, end)
+	}
+}
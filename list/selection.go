@@ -0,0 +1,169 @@
+package list
+
+import "sort"
+
+// SetMultiSelect enables or disables multi-select. When enabled, any number of
+// items can be selected in addition to the item under the cursor, by default
+// with the space key, and the default delegate renders a check mark beside
+// each item. Disabling multi-select clears the selection.
+//
+// Selections are kept against the items themselves rather than their
+// positions in the list, so they're preserved while filtering. The indices
+// used by the selection methods are indices into Items, as returned by
+// VisibleItemIndices.
+func (m *Model) SetMultiSelect(v bool) {
+	m.multiSelect = v
+	if !v {
+		m.ClearSelection()
+	}
+	m.ClearRenderCache()
+	m.updateKeybindings()
+}
+
+// MultiSelect returns whether or not multi-select is enabled.
+func (m Model) MultiSelect() bool {
+	return m.multiSelect
+}
+
+// SelectedItems returns the indices of the selected items, in ascending order.
+// See SetMultiSelect.
+func (m Model) SelectedItems() []int {
+	indices := make([]int, 0, len(m.selection))
+	for i := range m.selection {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// IsSelected returns whether or not the item at the given index is selected.
+func (m Model) IsSelected(index int) bool {
+	_, ok := m.selection[index]
+	return ok
+}
+
+// ToggleSelect selects the item at the given index if it's unselected, and
+// deselects it otherwise. It has no effect unless multi-select is enabled.
+func (m *Model) ToggleSelect(index int) {
+	if !m.multiSelect || index < 0 || index >= m.itemCount() {
+		return
+	}
+	if m.selection == nil {
+		m.selection = make(map[int]struct{})
+	}
+	if _, ok := m.selection[index]; ok {
+		delete(m.selection, index)
+	} else {
+		m.selection[index] = struct{}{}
+	}
+}
+
+// SelectAll selects every visible item, that is, every item matching the
+// filter if one is applied. It has no effect unless multi-select is enabled.
+func (m *Model) SelectAll() {
+	if !m.multiSelect {
+		return
+	}
+	if m.selection == nil {
+		m.selection = make(map[int]struct{})
+	}
+	for _, i := range m.VisibleItemIndices() {
+		m.selection[i] = struct{}{}
+	}
+}
+
+// ClearSelection deselects all items.
+func (m *Model) ClearSelection() {
+	m.selection = nil
+}
+
+// toggleSelectAll selects every visible item or, if they're all selected
+// already, deselects them.
+func (m *Model) toggleSelectAll() {
+	indices := m.VisibleItemIndices()
+	for _, i := range indices {
+		if !m.IsSelected(i) {
+			m.SelectAll()
+			return
+		}
+	}
+	for _, i := range indices {
+		delete(m.selection, i)
+	}
+}
+
+// itemIndex returns the index into Items of the item at the given index of
+// VisibleItems.
+func (m Model) itemIndex(index int) (int, bool) {
+	if index < 0 {
+		return 0, false
+	}
+	if m.filterState != Unfiltered {
+		if index >= len(m.filteredItems) {
+			return 0, false
+		}
+		return m.filteredItems[index].index, true
+	}
+	if m.treeMode {
+		if index >= len(m.treeVisible) {
+			return 0, false
+		}
+		return m.treeVisible[index], true
+	}
+	if index >= len(m.items) {
+		return 0, false
+	}
+	return index, true
+}
+
+// isVisibleItemSelected returns whether or not the item at the given index of
+// VisibleItems is selected.
+func (m Model) isVisibleItemSelected(index int) bool {
+	i, ok := m.itemIndex(index)
+	return ok && m.IsSelected(i)
+}
+
+// selectableItem returns the item at the given index, as used by the
+// selection.
+func (m Model) selectableItem(index int) Item {
+	if m.treeMode {
+		return m.treeNodes[index].item
+	}
+	return m.items[index]
+}
+
+// itemCount returns the number of items selections can refer to.
+func (m Model) itemCount() int {
+	if m.treeMode {
+		return len(m.treeNodes)
+	}
+	return len(m.items)
+}
+
+// shiftSelection updates the selection after an item has been inserted
+// (delta 1) or removed (delta -1) at the given index.
+func (m *Model) shiftSelection(index, delta int) {
+	if len(m.selection) == 0 {
+		return
+	}
+
+	// In tree mode an item's position depends on the number of descendants
+	// of the items before it, so there's no reliable way to shift.
+	if m.treeMode {
+		m.ClearSelection()
+		return
+	}
+
+	selection := make(map[int]struct{}, len(m.selection))
+	for i := range m.selection {
+		switch {
+		case i < index:
+			selection[i] = struct{}{}
+		case i == index && delta < 0:
+			// Removed
+		default:
+			selection[i+delta] = struct{}{}
+		}
+	}
+	m.selection = selection
+}
@@ -0,0 +1,57 @@
+package list
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectionWhileFiltered(t *testing.T) {
+	m := New(testItems(30), NewDefaultDelegate(), 80, 40)
+	m.SetMultiSelect(true)
+
+	m = press(typeFilter(m, "item 2"), "enter")
+	if m.FilterState() != FilterApplied {
+		t.Fatalf("expected filter state %s, got %s", FilterApplied, m.FilterState())
+	}
+
+	// Select the first and third matches
+	var want []int
+	for _, k := range []string{" ", "down", "down", " "} {
+		if k == " " {
+			i, _ := m.itemIndex(m.Index())
+			want = append(want, i)
+		}
+		m = press(m, k)
+	}
+	if want[0] == want[1] {
+		t.Fatalf("expected to select two different items, got %v", want)
+	}
+	if want[0] > want[1] {
+		want[0], want[1] = want[1], want[0]
+	}
+	for _, i := range want {
+		if got := m.Items()[i].FilterValue(); !strings.Contains(got, "2") {
+			t.Fatalf("expected selected items to match the filter, got %q", got)
+		}
+	}
+
+	if got := m.SelectedItems(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected items %v to be selected while filtered, got %v", want, got)
+	}
+
+	// Clearing the filter keeps the selection on the same items
+	m = press(m, "esc")
+	if m.FilterState() != Unfiltered {
+		t.Fatalf("expected filter state %s, got %s", Unfiltered, m.FilterState())
+	}
+	if got := m.SelectedItems(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected items %v to be selected after clearing the filter, got %v", want, got)
+	}
+	for i := range m.VisibleItems() {
+		selected := i == want[0] || i == want[1]
+		if m.isVisibleItemSelected(i) != selected {
+			t.Errorf("expected visible item %d selected to be %t", i, selected)
+		}
+	}
+}
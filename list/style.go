@@ -7,6 +7,17 @@ import (
 const (
 	bullet   = "•"
 	ellipsis = "…"
+	metaGap  = " "
+
+	// Tree mode.
+	treeIndent    = "  "
+	treeCollapsed = "▸ "
+	treeExpanded  = "▾ "
+	treeLeaf      = "  "
+
+	// Multi-select.
+	checkMark   = "✓ "
+	uncheckMark = "  "
 )
 
 // Styles contains style definitions for this list component. By default, these
@@ -18,6 +29,11 @@ type Styles struct {
 	FilterPrompt lipgloss.Style
 	FilterCursor lipgloss.Style
 
+	// Status messages shown beside the title. See NewStatusMessage and
+	// NewErrorStatusMessage.
+	StatusMessage      lipgloss.Style
+	StatusMessageError lipgloss.Style
+
 	// Default styling for matched characters in a filter. This can be
 	// overridden by delegates.
 	DefaultFilterCharacterMatch lipgloss.Style
@@ -61,6 +77,11 @@ func DefaultStyles() (s Styles) {
 	s.FilterCursor = lipgloss.NewStyle().
 		Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"})
 
+	s.StatusMessage = lipgloss.NewStyle()
+
+	s.StatusMessageError = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#FF4672", Dark: "#ED567A"})
+
 	s.DefaultFilterCharacterMatch = lipgloss.NewStyle().Underline(true)
 
 	s.StatusBar = lipgloss.NewStyle().
@@ -0,0 +1,283 @@
+package list
+
+import "strconv"
+
+// TreeItem is an item which can contain other items. In tree mode, items
+// implementing this interface can be expanded and collapsed to show and hide
+// their children.
+type TreeItem interface {
+	Item
+
+	// Children returns the item's child items, which may themselves be
+	// TreeItems.
+	Children() []Item
+}
+
+// TreeInfo describes an item's place in the tree when the list is in tree
+// mode. Delegates can use this to render indentation and expansion state.
+type TreeInfo struct {
+	// How deeply the item is nested. Top-level items have a depth of 0.
+	Depth int
+
+	// Whether or not the item has any children.
+	HasChildren bool
+
+	// Whether or not the item's children are currently shown.
+	Expanded bool
+}
+
+// treeNode is an item in the flattened tree.
+type treeNode struct {
+	item        Item
+	path        string // identifies the node's expansion state
+	depth       int
+	parent      int // index of the parent node, or -1 for top-level items
+	hasChildren bool
+}
+
+// SetTreeMode enables or disables tree mode. In tree mode the list's items are
+// the top-level items of a tree: items which implement TreeItem can be
+// expanded and collapsed to show and hide their children, and the list
+// renders the visible portion of the tree with indentation. Filtering
+// searches the whole tree and shows matches along with their ancestors.
+//
+// Note that in tree mode the indices used by VisibleItemIndices and the
+// multi-select methods refer to the depth-first order of every item in the
+// tree rather than to Items. Changing tree mode clears the selection.
+//
+// Whether an item is expanded is remembered by its position in the tree. To
+// keep items expanded as they're inserted, removed or reordered, implement
+// IdentifiableItem, in which case it's remembered by ID instead.
+func (m *Model) SetTreeMode(v bool) {
+	m.treeMode = v
+	if v && m.expanded == nil {
+		m.expanded = make(map[string]bool)
+	}
+	m.rebuildTree()
+	m.ClearSelection()
+	if m.filterState == Filtering || m.filterState == FilterApplied {
+		m.filteredItems = filteredItems(filterItems(*m)().(FilterMatchesMsg))
+	}
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// TreeMode returns whether or not tree mode is enabled.
+func (m Model) TreeMode() bool {
+	return m.treeMode
+}
+
+// TreeInfo returns information about the item at the given index of
+// VisibleItems in the tree. If the list isn't in tree mode, or the index is
+// out of bounds, the zero value is returned.
+func (m Model) TreeInfo(index int) TreeInfo {
+	n, ok := m.treeNodeAt(index)
+	if !ok {
+		return TreeInfo{}
+	}
+	node := m.treeNodes[n]
+
+	// An item is expanded if its children follow it. This holds both for
+	// the regular tree and for the filtered tree, where ancestors of
+	// matches are always shown expanded.
+	next, ok := m.treeNodeAt(index + 1)
+	return TreeInfo{
+		Depth:       node.depth,
+		HasChildren: node.hasChildren,
+		Expanded:    ok && m.treeNodes[next].parent == n,
+	}
+}
+
+// Expand shows the children of the selected item, if it has any. It has no
+// effect when not in tree mode or while filtering.
+func (m *Model) Expand() {
+	n, ok := m.selectedTreeNode()
+	if !ok || !m.treeNodes[n].hasChildren {
+		return
+	}
+	m.expanded[m.treeNodes[n].path] = true
+	m.rebuildTree()
+	m.updatePagination()
+}
+
+// Collapse hides the children of the selected item. If the selected item
+// isn't expanded the parent item is selected instead. It has no effect when
+// not in tree mode or while filtering.
+func (m *Model) Collapse() {
+	n, ok := m.selectedTreeNode()
+	if !ok {
+		return
+	}
+	node := m.treeNodes[n]
+
+	if node.hasChildren && m.expanded[node.path] {
+		delete(m.expanded, node.path)
+		m.rebuildTree()
+		m.updatePagination()
+		return
+	}
+
+	if node.parent < 0 {
+		return
+	}
+	for i, v := range m.treeVisible {
+		if v == node.parent {
+			m.Select(i)
+			return
+		}
+	}
+}
+
+// ExpandAll expands every item in the tree.
+func (m *Model) ExpandAll() {
+	if !m.treeMode {
+		return
+	}
+	for _, node := range m.treeNodes {
+		if node.hasChildren {
+			m.expanded[node.path] = true
+		}
+	}
+	m.rebuildTree()
+	m.updatePagination()
+}
+
+// CollapseAll collapses every item in the tree.
+func (m *Model) CollapseAll() {
+	if !m.treeMode {
+		return
+	}
+	m.expanded = make(map[string]bool)
+	m.rebuildTree()
+	m.updatePagination()
+}
+
+// selectedTreeNode returns the index of the node for the selected item. It's
+// only ok when the expansion state can be changed: in tree mode and while
+// unfiltered.
+func (m Model) selectedTreeNode() (int, bool) {
+	if !m.treeMode || m.filterState != Unfiltered {
+		return 0, false
+	}
+	return m.treeNodeAt(m.Index())
+}
+
+// treeNodeAt returns the index of the node for the item at the given index of
+// VisibleItems.
+func (m Model) treeNodeAt(index int) (int, bool) {
+	if !m.treeMode || index < 0 {
+		return 0, false
+	}
+
+	var n int
+	if m.filterState != Unfiltered {
+		if index >= len(m.filteredItems) {
+			return 0, false
+		}
+		n = m.filteredItems[index].index
+	} else {
+		if index >= len(m.treeVisible) {
+			return 0, false
+		}
+		n = m.treeVisible[index]
+	}
+
+	if n >= len(m.treeNodes) {
+		return 0, false
+	}
+	return n, true
+}
+
+// rebuildTree flattens the tree into every node, depth first, and the nodes
+// that are currently visible given the expansion state.
+func (m *Model) rebuildTree() {
+	m.treeNodes = nil
+	m.treeVisible = nil
+	m.ClearRenderCache()
+
+	if !m.treeMode {
+		return
+	}
+
+	var walk func(items []Item, depth, parent int, prefix string, visible bool)
+	walk = func(items []Item, depth, parent int, prefix string, visible bool) {
+		for i, item := range items {
+			path := strconv.Itoa(i)
+			if id, ok := itemID(item); ok {
+				// Quoted, so IDs can't be mistaken for positions
+				path = strconv.Quote(id)
+			} else if prefix != "" {
+				path = prefix + "/" + path
+			}
+
+			var children []Item
+			if t, ok := item.(TreeItem); ok {
+				children = t.Children()
+			}
+
+			n := len(m.treeNodes)
+			m.treeNodes = append(m.treeNodes, treeNode{
+				item:        item,
+				path:        path,
+				depth:       depth,
+				parent:      parent,
+				hasChildren: len(children) > 0,
+			})
+			if visible {
+				m.treeVisible = append(m.treeVisible, n)
+			}
+
+			walk(children, depth+1, n, path, visible && m.expanded[path])
+		}
+	}
+	walk(m.items, 0, -1, "", true)
+}
+
+// treeItems returns the items currently visible in the tree.
+func (m Model) treeItems() []Item {
+	items := make([]Item, len(m.treeVisible))
+	for i, n := range m.treeVisible {
+		items[i] = m.treeNodes[n].item
+	}
+	return items
+}
+
+// filterTree matches the term against every node in the tree. Matches are
+// returned in tree order along with their ancestors, so the tree structure
+// leading to each match is preserved.
+func filterTree(filter FilterFunc, term string, nodes []treeNode) []filteredItem {
+	targets := make([]string, len(nodes))
+	for i, n := range nodes {
+		targets[i] = n.item.FilterValue()
+	}
+
+	ranks := filter(term, targets)
+
+	matches := make(map[int]Rank, len(ranks))
+	include := make([]bool, len(nodes))
+	for _, r := range ranks {
+		matches[r.Index] = r
+
+		// Include the match and its ancestors. If we run into a node that's
+		// already included its ancestors are too, so we can stop there.
+		for i := r.Index; i >= 0 && !include[i]; i = nodes[i].parent {
+			include[i] = true
+		}
+	}
+
+	filterMatches := []filteredItem{}
+	for i, n := range nodes {
+		if !include[i] {
+			continue
+		}
+		r, matched := matches[i]
+		filterMatches = append(filterMatches, filteredItem{
+			index:   i,
+			item:    n.item,
+			matches: r.MatchedIndexes,
+			matched: matched,
+			score:   r.Score,
+		})
+	}
+	return filterMatches
+}
@@ -0,0 +1,60 @@
+package list
+
+import (
+	"reflect"
+	"testing"
+)
+
+type treeTestItem struct {
+	idItem
+	children []Item
+}
+
+func (i treeTestItem) Children() []Item { return i.children }
+
+// treeItems returns top-level items with the given IDs, each with one child.
+func treeItems(ids ...string) []Item {
+	items := make([]Item, len(ids))
+	for i, id := range ids {
+		items[i] = treeTestItem{
+			idItem:   idItem{testItem: testItem{title: id}, id: id},
+			children: idItems(id + "-child"),
+		}
+	}
+	return items
+}
+
+// visibleIDs returns the IDs of the visible items.
+func visibleIDs(m Model) []string {
+	var ids []string
+	for _, item := range m.VisibleItems() {
+		id, _ := itemID(item)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestTreeExpansionFollowsID(t *testing.T) {
+	m := New(treeItems("a", "b", "c"), NewDefaultDelegate(), 80, 40)
+	m.SetTreeMode(true)
+	m.Select(1)
+	m.Expand()
+
+	if got := visibleIDs(m); len(got) != 4 || got[2] != "b-child" {
+		t.Fatalf("expected b to be expanded, got %v", got)
+	}
+
+	// Inserting an item before b moves it, but it stays expanded
+	m.SetItems(treeItems("z", "a", "b", "c"))
+	want := []string{"z", "a", "b", "b-child", "c"}
+	if got := visibleIDs(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected visible items %v after inserting, got %v", want, got)
+	}
+
+	// As does reordering
+	m.SetItems(treeItems("b", "c", "a"))
+	want = []string{"b", "b-child", "c", "a"}
+	if got := visibleIDs(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected visible items %v after reordering, got %v", want, got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Type specifies the way we render pagination.
@@ -33,6 +34,18 @@ type Model struct {
 	UseUpDownKeys     bool
 	UseHLKeys         bool
 	UseJKKeys         bool
+	UseHomeEndKeys    bool
+
+	// DotStyleFunc is an optional function for styling each dot in the Dots
+	// view. It's called with the page index of the dot and whether or not
+	// it's the active page, and the returned style is applied to ActiveDot or
+	// InactiveDot accordingly.
+	DotStyleFunc func(index int, active bool) lipgloss.Style
+
+	// MaxDots is the maximum number of dots the Dots view will render. If
+	// there are more pages than this, the Arabic view is rendered instead.
+	// If 0 or less there's no maximum.
+	MaxDots int
 }
 
 // SetTotalPages is a helper function for calculating the total number of pages
@@ -91,6 +104,37 @@ func (m *Model) NextPage() {
 	}
 }
 
+// SetPage is a helper function for navigating directly to a page. Pages
+// outside of the valid range are clamped to the first or last page.
+func (m *Model) SetPage(n int) {
+	m.Page = max(0, min(n, m.TotalPages-1))
+}
+
+// FirstPage is a helper function for navigating to the first page.
+func (m *Model) FirstPage() {
+	m.Page = 0
+}
+
+// LastPage is a helper function for navigating to the last page.
+func (m *Model) LastPage() {
+	m.SetPage(m.TotalPages - 1)
+}
+
+// PageForItem returns the page the item at the given index is on, given
+// PerPage. Negative indices are on the first page.
+func (m Model) PageForItem(index int) int {
+	if index < 1 || m.PerPage < 1 {
+		return 0
+	}
+	return index / m.PerPage
+}
+
+// GotoItem is a helper function for navigating to the page the item at the
+// given index is on. See PageForItem.
+func (m *Model) GotoItem(index int) {
+	m.SetPage(m.PageForItem(index))
+}
+
 // OnLastPage returns whether or not we're on the last page.
 func (m Model) OnLastPage() bool {
 	return m.Page == m.TotalPages-1
@@ -111,6 +155,7 @@ func New() Model {
 		UseUpDownKeys:     false,
 		UseHLKeys:         true,
 		UseJKKeys:         false,
+		UseHomeEndKeys:    false,
 	}
 }
 
@@ -163,6 +208,14 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.NextPage()
 			}
 		}
+		if m.UseHomeEndKeys {
+			switch msg.String() {
+			case "home":
+				m.FirstPage()
+			case "end":
+				m.LastPage()
+			}
+		}
 	}
 
 	return m, nil
@@ -172,6 +225,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	switch m.Type {
 	case Dots:
+		if m.MaxDots > 0 && m.TotalPages > m.MaxDots {
+			return m.arabicView()
+		}
 		return m.dotsView()
 	default:
 		return m.arabicView()
@@ -181,11 +237,17 @@ func (m Model) View() string {
 func (m Model) dotsView() string {
 	var s string
 	for i := 0; i < m.TotalPages; i++ {
-		if i == m.Page {
-			s += m.ActiveDot
-			continue
+		active := i == m.Page
+
+		dot := m.InactiveDot
+		if active {
+			dot = m.ActiveDot
 		}
-		s += m.InactiveDot
+
+		if m.DotStyleFunc != nil {
+			dot = m.DotStyleFunc(i, active).Render(dot)
+		}
+		s += dot
 	}
 	return s
 }
@@ -200,3 +262,10 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
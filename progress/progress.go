@@ -1,6 +1,7 @@
 package progress
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/charmbracelet/harmonica"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lucasb-eyer/go-colorful"
+	"github.com/mattn/go-runewidth"
 	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
@@ -35,6 +37,10 @@ const (
 	defaultWidth     = 40
 	defaultFrequency = 18.0
 	defaultDamping   = 1.0
+
+	// Indeterminate mode.
+	defaultSegmentWidth = 8
+	defaultSegmentSpeed = 40.0 // cells per second
 )
 
 var color func(string) termenv.Color = termenv.ColorProfile().Color
@@ -82,6 +88,15 @@ func WithSolidFill(color string) Option {
 	}
 }
 
+// WithFillCharacters sets the characters used to render the filled and empty
+// portions of the progress bar. See Model.SetFillChars.
+func WithFillCharacters(full, empty rune) Option {
+	return func(m *Model) {
+		m.Full = full
+		m.Empty = empty
+	}
+}
+
 // WithoutPercentage hides the numeric percentage.
 func WithoutPercentage() Option {
 	return func(m *Model) {
@@ -110,6 +125,16 @@ func WithSpringOptions(frequency, damping float64) Option {
 	}
 }
 
+// WithIndeterminateSegment sets the width, in cells, and speed, in cells per
+// second, of the segment which moves across the bar in indeterminate mode.
+// See Model.SetIndeterminate.
+func WithIndeterminateSegment(width int, speed float64) Option {
+	return func(m *Model) {
+		m.SegmentWidth = width
+		m.SegmentSpeed = speed
+	}
+}
+
 // FrameMsg indicates that an animation step should occur.
 type FrameMsg struct {
 	id  int
@@ -141,6 +166,17 @@ type Model struct {
 	PercentFormat   string // a fmt string for a float
 	PercentageStyle lipgloss.Style
 
+	// Settings for the segment which moves across the bar in indeterminate
+	// mode: its width in cells and its speed in cells per second.
+	SegmentWidth int
+	SegmentSpeed float64
+
+	// Indeterminate mode state. The segment's position is the offset of its
+	// left edge, in cells, and it moves right while segmentDir is positive.
+	indeterminate bool
+	segmentPos    float64
+	segmentDir    float64
+
 	// Members for animated transitions.
 	spring           harmonica.Spring
 	springCustomized bool
@@ -170,6 +206,8 @@ func New(opts ...Option) Model {
 		EmptyColor:     "#606060",
 		ShowPercentage: true,
 		PercentFormat:  " %3.0f%%",
+		SegmentWidth:   defaultSegmentWidth,
+		SegmentSpeed:   defaultSegmentSpeed,
 	}
 	if !m.springCustomized {
 		m.SetSpringOptions(defaultFrequency, defaultDamping)
@@ -202,6 +240,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.indeterminate {
+			m.moveSegment()
+			return m, m.nextFrame()
+		}
+
 		// If we've more or less reached equilibrium, stop updating.
 		dist := math.Abs(m.percent - m.targetPercent)
 		if dist < 0.001 && m.velocity < 0.01 {
@@ -224,6 +267,68 @@ func (m *Model) SetSpringOptions(frequency, damping float64) {
 	m.spring = harmonica.NewSpring(harmonica.FPS(fps), frequency, damping)
 }
 
+// ErrInvalidFillChar is returned by SetFillChars when a fill character doesn't
+// occupy exactly one cell.
+var ErrInvalidFillChar = errors.New("fill characters must be a single cell wide")
+
+// SetFillChars sets the characters used to render the filled and empty
+// portions of the progress bar. This is handy for terminals or fonts which
+// don't render the default block characters well, in which case something
+// like "#" and "-" can be used instead. Gradients still apply to the filled
+// characters.
+//
+// Each character must be a single rune exactly one cell wide, otherwise
+// ErrInvalidFillChar is returned and the model is left unchanged.
+func (m *Model) SetFillChars(full, empty string) error {
+	f, ok := fillChar(full)
+	if !ok {
+		return ErrInvalidFillChar
+	}
+	e, ok := fillChar(empty)
+	if !ok {
+		return ErrInvalidFillChar
+	}
+	m.Full = f
+	m.Empty = e
+	return nil
+}
+
+// fillChar returns the rune in s if s is a single rune one cell wide.
+func fillChar(s string) (rune, bool) {
+	r := []rune(s)
+	if len(r) != 1 || runewidth.RuneWidth(r[0]) != 1 {
+		return 0, false
+	}
+	return r[0], true
+}
+
+// SetIndeterminate enables or disables indeterminate mode, for operations
+// whose progress can't be measured. Instead of a percentage, a segment of the
+// bar bounces back and forth across it. This returns a command to start the
+// animation, which is driven by FrameMsgs, so messages must be routed to
+// Update. The segment's size and speed are set with SegmentWidth and
+// SegmentSpeed.
+//
+// Calling SetPercent returns to determinate mode.
+func (m *Model) SetIndeterminate(v bool) tea.Cmd {
+	if v == m.indeterminate {
+		return nil
+	}
+	m.indeterminate = v
+	m.tag++
+	if !v {
+		return nil
+	}
+	m.segmentPos = 0
+	m.segmentDir = 1
+	return m.nextFrame()
+}
+
+// Indeterminate returns whether or not indeterminate mode is enabled.
+func (m Model) Indeterminate() bool {
+	return m.indeterminate
+}
+
 // Percent returns the current percentage state of the model. This is only
 // relevant when you're animating the progress bar.
 //
@@ -237,6 +342,7 @@ func (m Model) Percent() float64 {
 //
 // If you're rendering with ViewAs you won't need this.
 func (m *Model) SetPercent(p float64) tea.Cmd {
+	m.indeterminate = false
 	m.targetPercent = math.Max(0, math.Min(1, p))
 	m.tag++
 	return m.nextFrame()
@@ -264,10 +370,18 @@ func (m Model) View() string {
 	return m.ViewAs(m.percent)
 }
 
-// ViewAs renders the progress bar with a given percentage.
+// ViewAs renders the progress bar with a given percentage. In indeterminate
+// mode the percentage is ignored and the moving segment is rendered instead.
 func (m Model) ViewAs(percent float64) string {
 	b := strings.Builder{}
 	percentView := m.percentageView(percent)
+	if m.indeterminate {
+		// Keep the bar the same width, but there's no percentage to show
+		w := ansi.PrintableRuneWidth(percentView)
+		m.segmentView(&b, w)
+		b.WriteString(strings.Repeat(" ", w))
+		return b.String()
+	}
 	m.barView(&b, percent, ansi.PrintableRuneWidth(percentView))
 	b.WriteString(percentView)
 	return b.String()
@@ -315,6 +429,58 @@ func (m Model) barView(b *strings.Builder, percent float64, textWidth int) {
 	b.WriteString(strings.Repeat(e, n))
 }
 
+// moveSegment advances the indeterminate segment by one frame, bouncing it off
+// the ends of the bar.
+func (m *Model) moveSegment() {
+	maxPos := float64(max(0, m.barWidth()-m.segmentWidth()))
+	if m.segmentDir == 0 {
+		m.segmentDir = 1
+	}
+
+	m.segmentPos += m.segmentDir * m.SegmentSpeed / fps
+	if m.segmentPos >= maxPos {
+		m.segmentPos = maxPos
+		m.segmentDir = -1
+	} else if m.segmentPos <= 0 {
+		m.segmentPos = 0
+		m.segmentDir = 1
+	}
+}
+
+// segmentView renders the bar in indeterminate mode.
+func (m Model) segmentView(b *strings.Builder, textWidth int) {
+	var (
+		tw    = max(0, m.Width-textWidth) // total width
+		sw    = min(tw, m.segmentWidth()) // segment width
+		start = max(0, min(tw-sw, int(math.Round(m.segmentPos))))
+		e     = termenv.String(string(m.Empty)).Foreground(color(m.EmptyColor)).String()
+	)
+
+	b.WriteString(strings.Repeat(e, start))
+	for i := 0; i < sw; i++ {
+		c := m.FullColor
+		if m.useRamp {
+			// Spread the gradient across the segment
+			c = m.rampColorA.BlendLuv(m.rampColorB, float64(i)/float64(sw)).Hex()
+		}
+		b.WriteString(termenv.String(string(m.Full)).Foreground(color(c)).String())
+	}
+	b.WriteString(strings.Repeat(e, tw-start-sw))
+}
+
+// barWidth returns the width of the bar, excluding the percentage.
+func (m Model) barWidth() int {
+	return max(0, m.Width-ansi.PrintableRuneWidth(m.percentageView(0)))
+}
+
+// segmentWidth returns the width of the indeterminate segment.
+func (m Model) segmentWidth() int {
+	if m.SegmentWidth <= 0 {
+		return defaultSegmentWidth
+	}
+	return m.SegmentWidth
+}
+
 func (m Model) percentageView(percent float64) string {
 	if !m.ShowPercentage {
 		return ""
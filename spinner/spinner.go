@@ -25,12 +25,22 @@ func nextID() int {
 	return lastID
 }
 
-// Spinner is a set of frames used in animating the spinner.
+// Spinner is a set of frames used in animating the spinner. Despite its name,
+// FPS is the time each frame is shown for. To make a custom spinner:
+//
+//     s := spinner.New(spinner.WithSpinner(spinner.Spinner{
+//         Frames: []string{"◐", "◓", "◑", "◒"},
+//         FPS:    time.Second / 5,
+//     }))
 type Spinner struct {
 	Frames []string
 	FPS    time.Duration
 }
 
+// defaultFPS is the time each frame is shown for when neither the spinner nor
+// the model sets one.
+const defaultFPS = time.Second / 10 //nolint:gomnd
+
 // Some spinners to choose from. You could also make your own.
 var (
 	Line = Spinner{
@@ -110,6 +120,7 @@ type Model struct {
 	startTime time.Time
 	id        int
 	tag       int
+	fps       time.Duration
 }
 
 // Start resets resets the spinner start time. For use with MinimumLifetime and
@@ -193,12 +204,47 @@ func (m Model) Visible() bool {
 	return !m.hidden() && !m.finished()
 }
 
+// Option is used to set options in New. For example:
+//
+//     s := spinner.New(
+//         spinner.WithSpinner(spinner.Dot),
+//         spinner.WithFPS(time.Second/4),
+//     )
+type Option func(*Model)
+
+// WithSpinner sets the spinner, which can be one of the presets such as Dot or
+// a custom Spinner.
+func WithSpinner(s Spinner) Option {
+	return func(m *Model) {
+		m.Spinner = s
+	}
+}
+
+// WithFPS sets the time each frame is shown for, overriding the spinner's
+// own FPS. It stays in effect if the spinner is changed later.
+func WithFPS(d time.Duration) Option {
+	return func(m *Model) {
+		m.fps = d
+	}
+}
+
+// WithStyle sets the spinner's style.
+func WithStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.Style = s
+	}
+}
+
 // New returns a model with default values.
-func New() Model {
-	return Model{
+func New(opts ...Option) Model {
+	m := Model{
 		Spinner: Line,
 		id:      nextID(),
 	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
 }
 
 // NewModel returns a model with default values.
@@ -278,8 +324,20 @@ func (m Model) Tick() tea.Msg {
 	}
 }
 
+// interval returns how long each frame is shown for.
+func (m Model) interval() time.Duration {
+	switch {
+	case m.fps > 0:
+		return m.fps
+	case m.Spinner.FPS > 0:
+		return m.Spinner.FPS
+	default:
+		return defaultFPS
+	}
+}
+
 func (m Model) tick(id, tag int) tea.Cmd {
-	return tea.Tick(m.Spinner.FPS, func(t time.Time) tea.Msg {
+	return tea.Tick(m.interval(), func(t time.Time) tea.Msg {
 		return TickMsg{
 			Time: t,
 			ID:   id,
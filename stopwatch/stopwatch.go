@@ -43,11 +43,17 @@ type ResetMsg struct {
 	ID int
 }
 
+// LapMsg is sent when the stopwatch should record a lap.
+type LapMsg struct {
+	ID int
+}
+
 // Model for the stopwatch component.
 type Model struct {
 	d       time.Duration
 	id      int
 	running bool
+	laps    []time.Duration
 
 	// How long to wait before every tick. Defaults to 1 second.
 	Interval time.Duration
@@ -99,7 +105,14 @@ func (m Model) Toggle() tea.Cmd {
 	return m.Start()
 }
 
-// Reset restes the stopwatch to 0.
+// Lap records the time elapsed as a lap. See Laps and Splits.
+func (m Model) Lap() tea.Cmd {
+	return func() tea.Msg {
+		return LapMsg{ID: m.id}
+	}
+}
+
+// Reset restes the stopwatch to 0 and clears any laps.
 func (m Model) Reset() tea.Cmd {
 	return func() tea.Msg {
 		return ResetMsg{ID: m.id}
@@ -124,6 +137,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 		m.d = 0
+		m.laps = nil
+	case LapMsg:
+		if msg.ID != m.id {
+			return m, nil
+		}
+		m.laps = append(m.laps, m.d)
 	case TickMsg:
 		if !m.running || msg.ID != m.id {
 			break
@@ -140,6 +159,25 @@ func (m Model) Elapsed() time.Duration {
 	return m.d
 }
 
+// Laps returns the time elapsed when each lap was recorded, in order.
+func (m Model) Laps() []time.Duration {
+	laps := make([]time.Duration, len(m.laps))
+	copy(laps, m.laps)
+	return laps
+}
+
+// Splits returns the duration of each lap, that is, the time between it and
+// the lap before it, or the start for the first lap.
+func (m Model) Splits() []time.Duration {
+	splits := make([]time.Duration, len(m.laps))
+	var prev time.Duration
+	for i, d := range m.laps {
+		splits[i] = d - prev
+		prev = d
+	}
+	return splits
+}
+
 // View of the timer component.
 func (m Model) View() string {
 	return m.d.String()
@@ -0,0 +1,276 @@
+// Package testutil provides helpers for driving Bubbles components in tests
+// without a running Bubble Tea program. Keys are described by the same strings
+// used for keybindings, such as "down", "ctrl+c", "alt+b" or "q":
+//
+//     m := list.New(items, list.NewDefaultDelegate(), 80, 24)
+//     m, _ = testutil.PressList(m, "down", "down", "/")
+//     m, _ = testutil.TypeList(m, "apple")
+//
+// Components without a dedicated helper can be driven with Press by passing
+// a closure around their Update function.
+//
+// Commands produced along the way are run right away and their messages fed
+// back into the component, the way a Bubble Tea program would, so background
+// work such as filtering a list has finished by the time a helper returns.
+// Commands which take longer than CommandTimeout to produce a message, such as
+// timers and cursor blinks, aren't waited on. They're returned instead.
+package testutil
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lorenries/bubbles/list"
+	"github.com/lorenries/bubbles/paginator"
+	"github.com/lorenries/bubbles/textinput"
+	"github.com/lorenries/bubbles/viewport"
+)
+
+// CommandTimeout is how long to wait for commands to produce a message before
+// leaving them to the caller.
+var CommandTimeout = 20 * time.Millisecond
+
+// maxRounds limits how many rounds of commands producing further commands are
+// run, in case a component keeps producing commands which finish right away.
+const maxRounds = 100
+
+// keyTypes maps key names, as returned by tea.KeyMsg.String, to key types.
+var keyTypes = func() map[string]tea.KeyType {
+	m := make(map[string]tea.KeyType)
+
+	// Control characters
+	for t := tea.KeyType(0); t <= 31; t++ {
+		if s := t.String(); s != "" {
+			m[s] = t
+		}
+	}
+	m[tea.KeyBackspace.String()] = tea.KeyBackspace
+
+	// Other keys, such as the arrow keys
+	for t := tea.KeyUp; t >= tea.KeyDelete; t-- {
+		if s := t.String(); s != "" {
+			m[s] = t
+		}
+	}
+	m["delete"] = tea.KeyDelete
+
+	// Terminals send the spacebar as a regular rune
+	delete(m, "space")
+
+	return m
+}()
+
+// Key returns the key message Bubble Tea would send for the given key. The
+// key is described by its string representation, the same way keys are
+// described in keybindings. Anything that isn't a special key is treated as
+// runes, so Key("a") returns a message for the "a" key.
+func Key(s string) tea.KeyMsg {
+	var k tea.Key
+
+	if strings.HasPrefix(s, "alt+") && s != "alt+" {
+		k.Alt = true
+		s = strings.TrimPrefix(s, "alt+")
+	}
+
+	if s == "space" {
+		s = " "
+	}
+
+	if t, ok := keyTypes[s]; ok {
+		k.Type = t
+		return tea.KeyMsg(k)
+	}
+
+	k.Type = tea.KeyRunes
+	k.Runes = []rune(s)
+	return tea.KeyMsg(k)
+}
+
+// Keys returns key messages for each of the given keys. See Key.
+func Keys(keys ...string) []tea.KeyMsg {
+	msgs := make([]tea.KeyMsg, len(keys))
+	for i, k := range keys {
+		msgs[i] = Key(k)
+	}
+	return msgs
+}
+
+// Runes returns a key message for each rune in the given string, as if it were
+// typed out one character at a time.
+func Runes(s string) []tea.KeyMsg {
+	var msgs []tea.KeyMsg
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return msgs
+}
+
+// Press sends the given keys, in order, to an update function, running any
+// resulting commands and sending their messages to the update function too.
+// Commands which don't finish within CommandTimeout are returned, batched. The
+// update function will generally be a
+// closure around a component's Update method:
+//
+//     cmd := testutil.Press(func(msg tea.Msg) (cmd tea.Cmd) {
+//         m, cmd = m.Update(msg)
+//         return cmd
+//     }, "down", "enter")
+//
+func Press(update func(tea.Msg) tea.Cmd, keys ...string) tea.Cmd {
+	return send(update, Keys(keys...))
+}
+
+// PressList sends the given keys to a list and returns the updated model
+// along with any commands still pending, batched.
+func PressList(m list.Model, keys ...string) (list.Model, tea.Cmd) {
+	cmd := Press(func(msg tea.Msg) (cmd tea.Cmd) {
+		m, cmd = m.Update(msg)
+		return cmd
+	}, keys...)
+	return m, cmd
+}
+
+// TypeList types the given string into a list, one rune at a time. This is
+// useful for entering a filter.
+func TypeList(m list.Model, s string) (list.Model, tea.Cmd) {
+	cmd := send(func(msg tea.Msg) (cmd tea.Cmd) {
+		m, cmd = m.Update(msg)
+		return cmd
+	}, Runes(s))
+	return m, cmd
+}
+
+// PressTextInput sends the given keys to a text input and returns the updated
+// model along with any commands still pending, batched.
+func PressTextInput(m textinput.Model, keys ...string) (textinput.Model, tea.Cmd) {
+	cmd := Press(func(msg tea.Msg) (cmd tea.Cmd) {
+		m, cmd = m.Update(msg)
+		return cmd
+	}, keys...)
+	return m, cmd
+}
+
+// TypeTextInput types the given string into a text input, one rune at a
+// time.
+func TypeTextInput(m textinput.Model, s string) (textinput.Model, tea.Cmd) {
+	cmd := send(func(msg tea.Msg) (cmd tea.Cmd) {
+		m, cmd = m.Update(msg)
+		return cmd
+	}, Runes(s))
+	return m, cmd
+}
+
+// PressViewport sends the given keys to a viewport and returns the updated
+// model along with any commands still pending, batched.
+func PressViewport(m viewport.Model, keys ...string) (viewport.Model, tea.Cmd) {
+	cmd := Press(func(msg tea.Msg) (cmd tea.Cmd) {
+		m, cmd = m.Update(msg)
+		return cmd
+	}, keys...)
+	return m, cmd
+}
+
+// PressPaginator sends the given keys to a paginator and returns the updated
+// model along with any commands still pending, batched.
+func PressPaginator(m paginator.Model, keys ...string) (paginator.Model, tea.Cmd) {
+	cmd := Press(func(msg tea.Msg) (cmd tea.Cmd) {
+		m, cmd = m.Update(msg)
+		return cmd
+	}, keys...)
+	return m, cmd
+}
+
+func send(update func(tea.Msg) tea.Cmd, msgs []tea.KeyMsg) tea.Cmd {
+	var pending []tea.Cmd
+	for _, msg := range msgs {
+		if cmd := update(msg); cmd != nil {
+			pending = append(pending, run(update, []tea.Cmd{cmd})...)
+		}
+	}
+	return tea.Batch(pending...)
+}
+
+// cmdResult is the message produced by the command at the given index.
+type cmdResult struct {
+	index int
+	msg   tea.Msg
+}
+
+// run runs the given commands, sending their messages to the update function,
+// and then runs the commands that produces, and so on. Messages are sent in
+// the order of the commands which produced them. Commands which don't finish
+// within CommandTimeout are returned.
+func run(update func(tea.Msg) tea.Cmd, cmds []tea.Cmd) (pending []tea.Cmd) {
+	for round := 0; len(cmds) > 0 && round < maxRounds; round++ {
+		results := make(chan cmdResult, len(cmds))
+		for i, cmd := range cmds {
+			go func(i int, cmd tea.Cmd) {
+				results <- cmdResult{index: i, msg: cmd()}
+			}(i, cmd)
+		}
+
+		var (
+			done    []cmdResult
+			timeout = time.After(CommandTimeout)
+		)
+	wait:
+		for len(done) < len(cmds) {
+			select {
+			case r := <-results:
+				done = append(done, r)
+			case <-timeout:
+				break wait
+			}
+		}
+		sort.Slice(done, func(i, j int) bool {
+			return done[i].index < done[j].index
+		})
+
+		finished := make(map[int]bool, len(done))
+		var next []tea.Cmd
+		for _, r := range done {
+			finished[r.index] = true
+			if batch, ok := unbatch(r.msg); ok {
+				next = append(next, batch...)
+				continue
+			}
+			if r.msg == nil {
+				continue
+			}
+			if cmd := update(r.msg); cmd != nil {
+				next = append(next, cmd)
+			}
+		}
+		for i, cmd := range cmds {
+			if !finished[i] {
+				pending = append(pending, cmd)
+			}
+		}
+		cmds = next
+	}
+	return append(pending, cmds...)
+}
+
+// cmdType is the type of tea.Cmd.
+var cmdType = reflect.TypeOf((*tea.Cmd)(nil)).Elem()
+
+// unbatch returns the commands in a message produced by tea.Batch. Bubble Tea
+// doesn't export the message's type, so it's recognized as a slice of
+// commands.
+func unbatch(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if !v.IsValid() || v.Kind() != reflect.Slice || v.Type().Elem() != cmdType {
+		return nil, false
+	}
+
+	var cmds []tea.Cmd
+	for i := 0; i < v.Len(); i++ {
+		if cmd, ok := v.Index(i).Interface().(tea.Cmd); ok && cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds, true
+}
@@ -0,0 +1,53 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/lorenries/bubbles/list"
+)
+
+type item string
+
+func (i item) Title() string       { return string(i) }
+func (i item) Description() string { return "" }
+func (i item) FilterValue() string { return string(i) }
+
+func TestTypeListFilters(t *testing.T) {
+	m := list.New([]list.Item{item("apple"), item("banana"), item("cherry")}, list.NewDefaultDelegate(), 80, 24)
+
+	m, _ = PressList(m, "/")
+	m, _ = TypeList(m, "ban")
+	if m.FilterState() != list.Filtering {
+		t.Fatalf("expected filter state %s, got %s", list.Filtering, m.FilterState())
+	}
+	if n := len(m.VisibleItems()); n != 1 || m.VisibleItems()[0] != item("banana") {
+		t.Fatalf("expected only banana to be visible, got %v", m.VisibleItems())
+	}
+
+	m, _ = PressList(m, "enter")
+	if m.FilterState() != list.FilterApplied {
+		t.Fatalf("expected filter state %s, got %s", list.FilterApplied, m.FilterState())
+	}
+	if got := m.SelectedItem(); got != item("banana") {
+		t.Errorf("expected banana to be selected, got %v", got)
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"a", "a"},
+		{"down", "down"},
+		{"enter", "enter"},
+		{"ctrl+c", "ctrl+c"},
+		{"alt+b", "alt+b"},
+		{"space", " "},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.key).String(); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package textinput
+
+import "unicode"
+
+// SetSuggestions sets the suggestions to complete the value with. Suggestions
+// are only shown when ShowSuggestions is true.
+func (m *Model) SetSuggestions(suggestions []string) {
+	m.suggestions = make([]string, len(suggestions))
+	copy(m.suggestions, suggestions)
+	m.suggestionIndex = 0
+}
+
+// MatchedSuggestions returns the suggestions matching the current value, in
+// the order they were given. There are no matches while the value is empty.
+func (m Model) MatchedSuggestions() []string {
+	if len(m.value) == 0 {
+		return nil
+	}
+
+	match := m.MatchSuggestion
+	if match == nil {
+		match = PrefixMatch
+	}
+
+	var (
+		value   = string(m.value)
+		matches []string
+	)
+	for _, s := range m.suggestions {
+		if match(value, s) {
+			matches = append(matches, s)
+		}
+	}
+	return matches
+}
+
+// CurrentSuggestion returns the suggestion which would be accepted by
+// AcceptSuggestion, or an empty string if there isn't one.
+func (m Model) CurrentSuggestion() string {
+	if !m.ShowSuggestions {
+		return ""
+	}
+	matches := m.MatchedSuggestions()
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[m.suggestionIndex%len(matches)]
+}
+
+// AcceptSuggestion replaces the value with the current suggestion and moves
+// the cursor to the end. Returns whether or not there was a suggestion to
+// accept.
+func (m *Model) AcceptSuggestion() bool {
+	s := m.CurrentSuggestion()
+	if s == "" {
+		return false
+	}
+	m.SetValue(s)
+	m.CursorEnd()
+	return true
+}
+
+// NextSuggestion cycles to the next matching suggestion.
+func (m *Model) NextSuggestion() {
+	if n := len(m.MatchedSuggestions()); n > 0 {
+		m.suggestionIndex = (m.suggestionIndex%n + 1) % n
+	}
+}
+
+// PrevSuggestion cycles to the previous matching suggestion.
+func (m *Model) PrevSuggestion() {
+	if n := len(m.MatchedSuggestions()); n > 0 {
+		m.suggestionIndex = (m.suggestionIndex%n - 1 + n) % n
+	}
+}
+
+// PrefixMatch reports whether the suggestion starts with the value, ignoring
+// case. It's the default MatchSuggestion function.
+func PrefixMatch(value, suggestion string) bool {
+	return hasPrefixFold([]rune(suggestion), []rune(value))
+}
+
+// suggestionGhost returns the part of the current suggestion which hasn't
+// been typed yet, to be rendered after the cursor. This is only the case when
+// the cursor is at the end of the value and the suggestion starts with the
+// value; suggestions matched in other ways aren't previewed.
+func (m Model) suggestionGhost() []rune {
+	if !m.focus || m.pos != len(m.value) || m.EchoMode != EchoNormal {
+		return nil
+	}
+	s := []rune(m.CurrentSuggestion())
+	if len(s) <= len(m.value) || !hasPrefixFold(s, m.value) {
+		return nil
+	}
+	return s[len(m.value):]
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case.
+func hasPrefixFold(s, prefix []rune) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i, r := range prefix {
+		if unicode.ToLower(s[i]) != unicode.ToLower(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -47,6 +47,16 @@ type blinkCanceled struct{}
 type pasteMsg string
 type pasteErrMsg struct{ error }
 
+// ValidationResultMsg is the message produced by the command returned from
+// Model.AsyncValidate. Err should be nil if Value is valid.
+type ValidationResultMsg struct {
+	Value string
+	Err   error
+
+	// The ID of the text input that requested the validation.
+	id int
+}
+
 // EchoMode sets the input behavior of the text input field.
 type EchoMode int
 
@@ -93,6 +103,9 @@ func (c CursorMode) String() string {
 
 // Model is the Bubble Tea model for this text input element.
 type Model struct {
+	// Err holds the result of the most recent validation, or of a failed
+	// paste. It's nil when the value is valid. See Validate and
+	// AsyncValidate.
 	Err error
 
 	// General settings.
@@ -112,6 +125,14 @@ type Model struct {
 	PlaceholderStyle lipgloss.Style
 	CursorStyle      lipgloss.Style
 
+	// SuggestionStyle is applied to the preview of the current suggestion.
+	SuggestionStyle lipgloss.Style
+
+	// ErrStyle is applied to the text, on top of TextStyle, while the value
+	// fails Validate or AsyncValidate. Other errors, such as a failed paste,
+	// don't apply it. By default the text is rendered in red.
+	ErrStyle lipgloss.Style
+
 	// CharLimit is the maximum amount of characters this input element will
 	// accept. If 0 or less, there's no limit.
 	CharLimit int
@@ -121,6 +142,40 @@ type Model struct {
 	// viewport. If 0 or less this setting is ignored.
 	Width int
 
+	// ShowSuggestions enables completion from the suggestions given to
+	// SetSuggestions. As the user types, the first matching suggestion is
+	// previewed after the cursor. It can be accepted with tab, or with the
+	// right arrow at the end of the input, and ctrl+n and ctrl+p cycle
+	// through the other matches. The preview is never part of Value.
+	ShowSuggestions bool
+
+	// MatchSuggestion decides whether a suggestion matches the value. By
+	// default this is PrefixMatch.
+	MatchSuggestion func(value, suggestion string) bool
+
+	// Validate is an optional function which checks the value whenever it
+	// changes, whether by typing, pasting or SetValue. The result is stored
+	// in Err. Invalid input isn't rejected: it's up to you to decide what to
+	// do with an invalid value, such as preventing it from being submitted.
+	Validate func(string) error
+
+	// AsyncValidate is an optional function for validations that can't be
+	// performed immediately, such as checking with a server whether a
+	// username is taken. It's called whenever the user edits the value and
+	// should return a command that produces a ValidationResultMsg. The result
+	// is stored in Err. Results for values which have since changed are
+	// ignored. If Validate is also set, AsyncValidate is only called for
+	// values which pass Validate.
+	//
+	// SetValue can't return a command, so it doesn't call AsyncValidate; use
+	// Revalidate to validate a value set programmatically.
+	AsyncValidate func(string) tea.Cmd
+
+	// The indicator rendered after the input while an asynchronous validation
+	// is in flight.
+	ValidatingIndicator string
+	ValidatingStyle     lipgloss.Style
+
 	// The ID of this Model as it relates to other textinput Models.
 	id int
 
@@ -150,6 +205,14 @@ type Model struct {
 
 	// cursorMode determines the behavior of the cursor
 	cursorMode CursorMode
+
+	// Whether or not we're waiting on the result of AsyncValidate.
+	validating bool
+
+	// Suggestions to complete the value with, and which of the matching
+	// suggestions is current.
+	suggestions     []string
+	suggestionIndex int
 }
 
 // NewModel creates a new model with default settings.
@@ -160,6 +223,11 @@ func New() Model {
 		EchoCharacter:    '*',
 		CharLimit:        0,
 		PlaceholderStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		SuggestionStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		ErrStyle:         lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+
+		ValidatingIndicator: " …",
+		ValidatingStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 
 		id:         nextID(),
 		value:      nil,
@@ -181,6 +249,7 @@ var NewModel = New
 
 // SetValue sets the value of the text input.
 func (m *Model) SetValue(s string) {
+	old := string(m.value)
 	runes := []rune(s)
 	if m.CharLimit > 0 && len(runes) > m.CharLimit {
 		m.value = runes[:m.CharLimit]
@@ -191,6 +260,32 @@ func (m *Model) SetValue(s string) {
 		m.setCursor(len(m.value))
 	}
 	m.handleOverflow()
+	m.suggestionIndex = 0
+	m.valueSet(old)
+}
+
+// valueSet runs Validate after the value has been changed programmatically
+// from old.
+func (m *Model) valueSet(old string) {
+	if m.Validate != nil {
+		m.Err = m.Validate(string(m.value))
+	}
+
+	// Any asynchronous result in flight is for the old value, and will be
+	// ignored, so the new value is unvalidated until Revalidate is called.
+	if m.AsyncValidate != nil && string(m.value) != old {
+		m.validating = false
+		if m.Validate == nil {
+			m.Err = nil
+		}
+	}
+}
+
+// Revalidate runs Validate against the current value and, if it passes,
+// returns a command to run AsyncValidate. Use it after SetValue, or when
+// whatever the validators check against has changed.
+func (m *Model) Revalidate() tea.Cmd {
+	return m.validate()
 }
 
 // Value returns the value of the text input.
@@ -263,6 +358,54 @@ func (m *Model) cursorEnd() bool {
 	return m.setCursor(len(m.value))
 }
 
+// Validating returns whether or not an asynchronous validation is in flight.
+func (m Model) Validating() bool {
+	return m.validating
+}
+
+// validate runs Validate against the current value, storing the result in Err.
+// If the value is valid, it then returns a command to run AsyncValidate.
+func (m *Model) validate() tea.Cmd {
+	if m.Validate != nil {
+		m.Err = m.Validate(string(m.value))
+		if m.Err != nil {
+			// There's no point waiting on a value we know is invalid
+			m.validating = false
+			return nil
+		}
+	} else if m.AsyncValidate != nil {
+		// The previous value's error doesn't apply to this one, which
+		// hasn't been checked yet
+		m.Err = nil
+	}
+	return m.asyncValidate()
+}
+
+// asyncValidate returns a command which runs AsyncValidate against the current
+// value, tagging the result so that it's only received by this input.
+func (m *Model) asyncValidate() tea.Cmd {
+	if m.AsyncValidate == nil {
+		return nil
+	}
+
+	cmd := m.AsyncValidate(string(m.value))
+	if cmd == nil {
+		m.validating = false
+		return nil
+	}
+
+	m.validating = true
+	id := m.id
+	return func() tea.Msg {
+		msg := cmd()
+		if r, ok := msg.(ValidationResultMsg); ok {
+			r.id = id
+			return r
+		}
+		return msg
+	}
+}
+
 // Focused returns the focus state on the model.
 func (m Model) Focused() bool {
 	return m.focus
@@ -290,7 +433,10 @@ func (m *Model) Blur() {
 // Reset sets the input to its default state with no input. Returns whether
 // or not the cursor blink should reset.
 func (m *Model) Reset() bool {
+	old := string(m.value)
 	m.value = nil
+	m.suggestionIndex = 0
+	m.valueSet(old)
 	return m.setCursor(0)
 }
 
@@ -571,12 +717,28 @@ func (m Model) echoTransform(v string) string {
 
 // Update is the Bubble Tea update loop.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// Validation results are accepted even when we're not focused, since the
+	// user may have moved on before the result arrived.
+	if msg, ok := msg.(ValidationResultMsg); ok {
+		// Ignore results meant for other inputs, and results for values
+		// which are no longer current.
+		if msg.id != m.id || msg.Value != string(m.value) {
+			return m, nil
+		}
+		m.validating = false
+		m.Err = msg.Err
+		return m, nil
+	}
+
 	if !m.focus {
 		m.blink = true
 		return m, nil
 	}
 
-	var resetBlink bool
+	var (
+		resetBlink bool
+		oldValue   = string(m.value)
+	)
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -607,7 +769,17 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			if m.pos < len(m.value) { // right arrow, ^F, forward one character
 				resetBlink = m.setCursor(m.pos + 1)
+				break
+			}
+			if msg.Type == tea.KeyRight { // right arrow at the end, accept suggestion
+				m.AcceptSuggestion()
 			}
+		case tea.KeyTab: // accept suggestion
+			m.AcceptSuggestion()
+		case tea.KeyCtrlN: // ^N, next suggestion
+			m.NextSuggestion()
+		case tea.KeyCtrlP: // ^P, previous suggestion
+			m.PrevSuggestion()
 		case tea.KeyCtrlW: // ^W, delete word left of cursor
 			resetBlink = m.deleteWordLeft()
 		case tea.KeyHome, tea.KeyCtrlA: // ^A, go to beginning
@@ -688,13 +860,18 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.Err = msg
 	}
 
-	var cmd tea.Cmd
+	var cmds []tea.Cmd
 	if resetBlink {
-		cmd = m.blinkCmd()
+		cmds = append(cmds, m.blinkCmd())
+	}
+
+	if string(m.value) != oldValue {
+		m.suggestionIndex = 0
+		cmds = append(cmds, m.validate())
 	}
 
 	m.handleOverflow()
-	return m, cmd
+	return m, tea.Batch(cmds...)
 }
 
 // View renders the textinput in its current state.
@@ -704,30 +881,59 @@ func (m Model) View() string {
 		return m.placeholderView()
 	}
 
-	styleText := m.TextStyle.Inline(true).Render
+	styleText := m.textStyle().Inline(true).Render
 
 	value := m.value[m.offset:m.offsetRight]
 	pos := max(0, m.pos-m.offset)
 	v := styleText(m.echoTransform(string(value[:pos])))
 
+	valWidth := rw.StringWidth(string(value))
+
+	// Preview of the current suggestion, cut to fit the width, if any
+	ghost := m.suggestionGhost()
+	if m.Width > 0 {
+		w := 0
+		for i, r := range ghost {
+			if w += rw.RuneWidth(r); valWidth+w > m.Width+1 {
+				ghost = ghost[:i]
+				break
+			}
+		}
+	}
+
 	if pos < len(value) {
 		v += m.cursorView(m.echoTransform(string(value[pos]))) // cursor and text under it
 		v += styleText(m.echoTransform(string(value[pos+1:]))) // text after cursor
+	} else if len(ghost) > 0 {
+		styleSuggestion := m.SuggestionStyle.Inline(true).Render
+		if m.blink {
+			v += styleSuggestion(string(ghost[0]))
+		} else {
+			v += m.cursorView(string(ghost[0])) // cursor over the suggestion
+		}
+		v += styleSuggestion(string(ghost[1:]))
 	} else {
 		v += m.cursorView(" ")
 	}
 
 	// If a max width and background color were set fill the empty spaces with
 	// the background color.
-	valWidth := rw.StringWidth(string(value))
 	if m.Width > 0 && valWidth <= m.Width {
 		padding := max(0, m.Width-valWidth)
 		if valWidth+padding <= m.Width && pos < len(value) {
 			padding++
 		}
+		if len(ghost) > 0 {
+			// The suggestion takes the place of the cursor's trailing space
+			padding = max(0, padding+1-rw.StringWidth(string(ghost)))
+		}
 		v += styleText(strings.Repeat(" ", padding))
 	}
 
+	if m.validating {
+		v += m.ValidatingStyle.Inline(true).Render(m.ValidatingIndicator)
+	}
+
 	return m.PromptStyle.Render(m.Prompt) + v
 }
 
@@ -752,10 +958,27 @@ func (m Model) placeholderView() string {
 	return m.PromptStyle.Render(m.Prompt) + v
 }
 
+// textStyle returns the style for the text, accounting for the error state.
+func (m Model) textStyle() lipgloss.Style {
+	if m.invalid() {
+		return m.ErrStyle.Copy().Inherit(m.TextStyle)
+	}
+	return m.TextStyle
+}
+
+// invalid returns whether or not Err holds a validation error.
+func (m Model) invalid() bool {
+	if m.Err == nil || (m.Validate == nil && m.AsyncValidate == nil) {
+		return false
+	}
+	_, paste := m.Err.(pasteErrMsg)
+	return !paste
+}
+
 // cursorView styles the cursor.
 func (m Model) cursorView(v string) string {
 	if m.blink {
-		return m.TextStyle.Render(v)
+		return m.textStyle().Render(v)
 	}
 	return m.CursorStyle.Inline(true).Reverse(true).Render(v)
 }
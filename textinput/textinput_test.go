@@ -0,0 +1,86 @@
+package textinput
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// asyncInput returns a focused input whose AsyncValidate rejects "bob".
+func asyncInput() Model {
+	m := New()
+	m.Focus()
+	m.AsyncValidate = func(s string) tea.Cmd {
+		return func() tea.Msg {
+			var err error
+			if s == "bob" {
+				err = errors.New("taken")
+			}
+			return ValidationResultMsg{Value: s, Err: err}
+		}
+	}
+	return m
+}
+
+// typeText types s into the input.
+func typeText(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestResetClearsValidating(t *testing.T) {
+	m := typeText(asyncInput(), "bob")
+	if !m.Validating() {
+		t.Fatal("expected a validation to be in flight")
+	}
+
+	m.Reset()
+	if m.Validating() {
+		t.Error("expected Reset to stop waiting on the validation in flight")
+	}
+	if m.Err != nil {
+		t.Errorf("expected no error after Reset, got %v", m.Err)
+	}
+}
+
+func TestEditClearsAsyncError(t *testing.T) {
+	m := typeText(asyncInput(), "bob")
+	m, _ = m.Update(m.Revalidate()())
+	if m.Err == nil {
+		t.Fatal("expected bob to be rejected")
+	}
+
+	m = typeText(m, "b")
+	if !m.Validating() {
+		t.Fatal("expected a validation to be in flight")
+	}
+	if m.Err != nil {
+		t.Errorf("expected no error while the new value is validated, got %v", m.Err)
+	}
+}
+
+func TestErrStyleOnlyForValidationErrors(t *testing.T) {
+	m := New()
+	m.Focus()
+	m, _ = m.Update(pasteErrMsg{errors.New("no clipboard")})
+	if m.Err == nil {
+		t.Fatal("expected the paste error to be stored in Err")
+	}
+	if m.invalid() {
+		t.Error("expected a paste error not to be styled as invalid")
+	}
+
+	m.Validate = func(s string) error {
+		if s == "" {
+			return errors.New("required")
+		}
+		return nil
+	}
+	m.SetValue("")
+	if !m.invalid() {
+		t.Error("expected a validation error to be styled as invalid")
+	}
+}
@@ -67,6 +67,15 @@ type TickMsg struct {
 	// Timeout returns whether or not this tick is a timeout tick. You can
 	// alternatively listen for TimeoutMsg.
 	Timeout bool
+
+	// Remaining is the time left on the timer as of this tick. Ticks are sent
+	// every Interval, so this can be used to perform side effects, such as
+	// playing a sound, at each step of the countdown.
+	Remaining time.Duration
+
+	// tag identifies the run of the timer the tick belongs to, so ticks sent
+	// before the timer was paused are ignored once it's resumed.
+	tag int
 }
 
 // TimeoutMsg is a message that is sent once when the timer times out.
@@ -86,6 +95,7 @@ type Model struct {
 	Interval time.Duration
 
 	id      int
+	tag     int
 	running bool
 }
 
@@ -136,10 +146,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if msg.ID != 0 && msg.ID != m.id {
 			return m, nil
 		}
+		if msg.running == m.running {
+			return m, nil
+		}
 		m.running = msg.running
+
+		// Start a new run of ticks, invalidating any still in flight.
+		m.tag++
+		if !m.Running() {
+			return m, nil
+		}
 		return m, m.tick()
 	case TickMsg:
-		if !m.Running() || (msg.ID != 0 && msg.ID != m.id) {
+		if !m.Running() || (msg.ID != 0 && msg.ID != m.id) || msg.tag != m.tag {
 			break
 		}
 
@@ -162,9 +181,18 @@ func (m *Model) Start() tea.Cmd {
 
 // Stop pauses the timer. Has no effect if the timer has timed out.
 func (m *Model) Stop() tea.Cmd {
-	return func() tea.Msg {
-		return m.startStop(false)
-	}
+	return m.startStop(false)
+}
+
+// Pause pauses the timer, keeping the time remaining. It's the same as Stop.
+func (m *Model) Pause() tea.Cmd {
+	return m.Stop()
+}
+
+// Resume resumes a paused timer from where it left off. It's the same as
+// Start.
+func (m *Model) Resume() tea.Cmd {
+	return m.Start()
 }
 
 // Toggle stops the timer if it's running and starts it if it's stopped.
@@ -173,8 +201,13 @@ func (m *Model) Toggle() tea.Cmd {
 }
 
 func (m Model) tick() tea.Cmd {
+	// By the time this tick arrives another interval will have elapsed.
+	remaining := m.Timeout - m.Interval
+	if remaining < 0 {
+		remaining = 0
+	}
 	return tea.Tick(m.Interval, func(_ time.Time) tea.Msg {
-		return TickMsg{ID: m.id, Timeout: m.Timedout()}
+		return TickMsg{ID: m.id, Timeout: m.Timedout(), Remaining: remaining, tag: m.tag}
 	})
 }
 
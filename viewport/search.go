@@ -0,0 +1,209 @@
+package viewport
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+// Match is the position of a search term in the viewport's content. Columns
+// and lengths are measured in runes, ignoring any ANSI escape sequences in the
+// content.
+type Match struct {
+	Line   int
+	Column int
+	Length int
+}
+
+// Search returns the positions of every occurrence of term in the content, in
+// order. Matching is case-insensitive and ignores ANSI styling, so a term
+// is found even if it's styled differently part way through.
+func (m Model) Search(term string) []Match {
+	return searchLines(term, m.lines, 0)
+}
+
+// HighlightSearch highlights every occurrence of term in the content with
+// the given style. The highlights are applied when the content is rendered
+// on top of any existing styling, so the content itself isn't changed.
+// Highlights are kept up to date as content is set or appended. Use NextMatch
+// and PrevMatch to scroll between matches.
+//
+// Highlighting an empty term clears the search.
+func (m *Model) HighlightSearch(term string, style lipgloss.Style) {
+	if term == "" {
+		m.ClearSearch()
+		return
+	}
+	m.searchTerm = term
+	m.searchStyle = style
+	m.matches = m.Search(term)
+	m.matchIndex = -1
+}
+
+// ClearSearch removes search highlighting.
+func (m *Model) ClearSearch() {
+	m.searchTerm = ""
+	m.matches = nil
+	m.matchIndex = -1
+}
+
+// SearchMatches returns the matches currently highlighted. See
+// HighlightSearch.
+func (m Model) SearchMatches() []Match {
+	return m.matches
+}
+
+// NextMatch scrolls to the next highlighted match, wrapping around to the
+// first one after the last. The match is centered where possible. Returns
+// whether or not there are any matches.
+func (m *Model) NextMatch() bool {
+	if len(m.matches) == 0 {
+		return false
+	}
+	m.gotoMatch((m.matchIndex + 1) % len(m.matches))
+	return true
+}
+
+// PrevMatch scrolls to the previous highlighted match, wrapping around to the
+// last one before the first. The match is centered where possible. Returns
+// whether or not there are any matches.
+func (m *Model) PrevMatch() bool {
+	if len(m.matches) == 0 {
+		return false
+	}
+	i := m.matchIndex - 1
+	if i < 0 {
+		i = len(m.matches) - 1
+	}
+	m.gotoMatch(i)
+	return true
+}
+
+func (m *Model) gotoMatch(i int) {
+	m.matchIndex = i
+	m.SetYOffset(m.matches[i].Line - m.Height/2) //nolint:gomnd
+}
+
+// searchLines finds the term in the given lines, numbering lines from offset.
+func searchLines(term string, lines []string, offset int) []Match {
+	needle := []rune(strings.ToLower(term))
+	for i, r := range needle {
+		needle[i] = unicode.ToLower(r)
+	}
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var matches []Match
+	for n, l := range lines {
+		haystack := stripANSI(l)
+		for i, r := range haystack {
+			haystack[i] = unicode.ToLower(r)
+		}
+
+		for i := 0; i+len(needle) <= len(haystack); {
+			if string(haystack[i:i+len(needle)]) != string(needle) {
+				i++
+				continue
+			}
+			matches = append(matches, Match{
+				Line:   offset + n,
+				Column: i,
+				Length: len(needle),
+			})
+			i += len(needle)
+		}
+	}
+	return matches
+}
+
+// stripANSI returns the printable runes in s.
+func stripANSI(s string) []rune {
+	var (
+		out    = make([]rune, 0, len(s))
+		inANSI bool
+	)
+	for _, r := range s {
+		switch {
+		case r == ansi.Marker:
+			inANSI = true
+		case inANSI:
+			inANSI = !ansi.IsTerminator(r)
+		default:
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// highlightLine renders the search matches on the given line of content.
+func (m Model) highlightLine(line int, s string) string {
+	i := sort.Search(len(m.matches), func(i int) bool {
+		return m.matches[i].Line >= line
+	})
+	var matches []Match
+	for ; i < len(m.matches) && m.matches[i].Line == line; i++ {
+		matches = append(matches, m.matches[i])
+	}
+	if len(matches) == 0 {
+		return s
+	}
+
+	var (
+		out     strings.Builder
+		match   strings.Builder // text of the current match, unstyled
+		seq     strings.Builder // the current escape sequence
+		seqs    strings.Builder // every escape sequence so far
+		inANSI  bool
+		col     int
+		inMatch bool
+	)
+	for _, r := range s {
+		switch {
+		case r == ansi.Marker:
+			inANSI = true
+			seq.Reset()
+			seq.WriteRune(r)
+			continue
+
+		case inANSI:
+			seq.WriteRune(r)
+			if ansi.IsTerminator(r) {
+				inANSI = false
+				seqs.WriteString(seq.String())
+
+				// Sequences within a match are replayed after it
+				if !inMatch {
+					out.WriteString(seq.String())
+				}
+			}
+			continue
+		}
+
+		if !inMatch && len(matches) > 0 && col == matches[0].Column {
+			inMatch = true
+		}
+
+		if !inMatch {
+			out.WriteRune(r)
+			col++
+			continue
+		}
+
+		match.WriteRune(r)
+		col++
+		if col == matches[0].Column+matches[0].Length {
+			// Flush, then restore the line's own styling
+			out.WriteString(m.searchStyle.Render(match.String()))
+			out.WriteString(seqs.String())
+			match.Reset()
+			matches = matches[1:]
+			inMatch = false
+		}
+	}
+
+	return out.String()
+}
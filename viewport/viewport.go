@@ -2,11 +2,13 @@ package viewport
 
 import (
 	"math"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lorenries/bubbles/key"
+	"github.com/lorenries/bubbles/spinner"
 )
 
 // New returns a new model with the given width and height as well as default
@@ -52,14 +54,45 @@ type Model struct {
 	// which is usually via the alternate screen buffer.
 	HighPerformanceRendering bool
 
+	// MarkIndicator is rendered in a gutter to the left of marked lines.
+	// The gutter is only shown when at least one line is marked.
+	MarkIndicator string
+	MarkStyle     lipgloss.Style
+
 	initialized bool
 	lines       []string
+
+	// Follow mode. When enabled the viewport sticks to the bottom as content
+	// is added, like tail -f, unless the user has scrolled away from it.
+	followEnabled bool
+	following     bool
+
+	// Loading state. While loading, a spinner is rendered in the center of
+	// the viewport in place of the content.
+	loading bool
+	spinner spinner.Model
+
+	// Marked lines, sorted, and the mark most recently jumped to.
+	marks    []int
+	lastMark int
+
+	// Search highlighting. Matches are sorted by position, and matchIndex is
+	// the match most recently scrolled to.
+	searchTerm  string
+	searchStyle lipgloss.Style
+	matches     []Match
+	matchIndex  int
 }
 
 func (m *Model) setInitialValues() {
 	m.KeyMap = DefaultKeyMap()
 	m.MouseWheelEnabled = true
 	m.MouseWheelDelta = 3
+	m.spinner = spinner.New()
+	m.spinner.Spinner = spinner.Dot
+	m.MarkIndicator = "▌"
+	m.lastMark = -1
+	m.matchIndex = -1
 	m.initialized = true
 }
 
@@ -103,11 +136,218 @@ func (m *Model) SetContent(s string) {
 	s = strings.ReplaceAll(s, "\r\n", "\n") // normalize line endings
 	m.lines = strings.Split(s, "\n")
 
-	if m.YOffset > len(m.lines)-1 {
+	// Drop marks beyond the end of the new content
+	if i := sort.SearchInts(m.marks, len(m.lines)); i < len(m.marks) {
+		m.marks = m.marks[:i]
+	}
+
+	if m.searchTerm != "" {
+		m.matches = m.Search(m.searchTerm)
+		m.matchIndex = -1
+	}
+
+	if m.YOffset > len(m.lines)-1 || m.Following() {
 		m.GotoBottom()
 	}
 }
 
+// AppendLines adds lines to the end of the viewport's content. Unlike
+// SetContent, only the new lines are processed, which makes this much cheaper
+// for content which grows continually, such as logs. Strings containing line
+// breaks are split into multiple lines. For high performance rendering the
+// Sync command should also be called.
+func (m *Model) AppendLines(lines []string) {
+	start := len(m.lines)
+	for _, l := range lines {
+		if !strings.ContainsAny(l, "\r\n") {
+			m.lines = append(m.lines, l)
+			continue
+		}
+		l = strings.ReplaceAll(l, "\r\n", "\n") // normalize line endings
+		m.lines = append(m.lines, strings.Split(l, "\n")...)
+	}
+
+	if m.searchTerm != "" {
+		m.matches = append(m.matches, searchLines(m.searchTerm, m.lines[start:], start)...)
+	}
+
+	if m.Following() {
+		m.GotoBottom()
+	}
+}
+
+// SetLoading shows or hides the loading state. While loading, a spinner is
+// rendered in the center of the viewport instead of its content. Content can
+// still be set while loading and will be revealed once loading is finished.
+// Note that this returns a command to start the spinner.
+//
+// The loading state is not rendered with HighPerformanceRendering.
+func (m *Model) SetLoading(v bool) tea.Cmd {
+	if !m.initialized {
+		m.setInitialValues()
+	}
+	if v == m.loading {
+		return nil
+	}
+	m.loading = v
+	if v {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// Loading returns whether or not the viewport is in the loading state.
+func (m Model) Loading() bool {
+	return m.loading
+}
+
+// SetSpinner sets the spinner used to indicate loading.
+func (m *Model) SetSpinner(s spinner.Spinner) {
+	if !m.initialized {
+		m.setInitialValues()
+	}
+	m.spinner.Spinner = s
+}
+
+// SetSpinnerStyle sets the style of the spinner used to indicate loading.
+func (m *Model) SetSpinnerStyle(s lipgloss.Style) {
+	if !m.initialized {
+		m.setInitialValues()
+	}
+	m.spinner.Style = s
+}
+
+// SetFollow enables or disables follow mode. In follow mode the viewport
+// scrolls to the bottom whenever content is added. Scrolling up away from the
+// bottom pauses following, and scrolling back down to the bottom resumes it.
+func (m *Model) SetFollow(v bool) {
+	m.followEnabled = v
+	m.following = v
+	if v {
+		m.GotoBottom()
+	}
+}
+
+// Following returns whether or not the viewport is currently following new
+// content. This is false if follow mode is disabled, or if it's enabled but
+// the user has scrolled up away from the bottom.
+func (m Model) Following() bool {
+	return m.followEnabled && m.following
+}
+
+// TotalLineCount returns the total number of lines in the viewport's content.
+func (m Model) TotalLineCount() int {
+	return len(m.lines)
+}
+
+// VisibleLineCount returns the number of lines currently visible in the
+// viewport.
+func (m Model) VisibleLineCount() int {
+	return len(m.visibleLines())
+}
+
+// AddMark marks the given line of the content. Marked lines are indicated in a
+// gutter and can be jumped between with NextMark and PrevMark. Marks refer to
+// lines of content, so they stay with their lines as the viewport scrolls and
+// as content is appended.
+func (m *Model) AddMark(line int) {
+	if line < 0 || line >= len(m.lines) {
+		return
+	}
+	i := sort.SearchInts(m.marks, line)
+	if i < len(m.marks) && m.marks[i] == line {
+		return
+	}
+	m.marks = append(m.marks, 0)
+	copy(m.marks[i+1:], m.marks[i:])
+	m.marks[i] = line
+}
+
+// RemoveMark removes the mark from the given line, if any.
+func (m *Model) RemoveMark(line int) {
+	i := sort.SearchInts(m.marks, line)
+	if i < len(m.marks) && m.marks[i] == line {
+		m.marks = append(m.marks[:i], m.marks[i+1:]...)
+	}
+}
+
+// ToggleMark marks the given line if it's unmarked, and unmarks it otherwise.
+func (m *Model) ToggleMark(line int) {
+	if m.Marked(line) {
+		m.RemoveMark(line)
+		return
+	}
+	m.AddMark(line)
+}
+
+// Marked returns whether or not the given line is marked.
+func (m Model) Marked(line int) bool {
+	i := sort.SearchInts(m.marks, line)
+	return i < len(m.marks) && m.marks[i] == line
+}
+
+// Marks returns the marked lines in ascending order.
+func (m Model) Marks() []int {
+	marks := make([]int, len(m.marks))
+	copy(marks, m.marks)
+	return marks
+}
+
+// ClearMarks removes all marks.
+func (m *Model) ClearMarks() {
+	m.marks = nil
+	m.lastMark = -1
+}
+
+// NextMark scrolls to the next marked line after the one most recently jumped
+// to, or after the top of the viewport if that mark is no longer in view. The
+// marked line is centered where possible. Returns whether or not there was a
+// mark to jump to.
+func (m *Model) NextMark() bool {
+	from, ok := m.markReference()
+	if !ok {
+		from = m.YOffset - 1
+	}
+	i := sort.SearchInts(m.marks, from+1)
+	if i >= len(m.marks) {
+		return false
+	}
+	m.gotoMark(m.marks[i])
+	return true
+}
+
+// PrevMark scrolls to the previous marked line before the one most recently
+// jumped to, or before the top of the viewport if that mark is no longer in
+// view. The marked line is centered where possible. Returns whether or not
+// there was a mark to jump to.
+func (m *Model) PrevMark() bool {
+	from, ok := m.markReference()
+	if !ok {
+		from = m.YOffset
+	}
+	i := sort.SearchInts(m.marks, from) - 1
+	if i < 0 {
+		return false
+	}
+	m.gotoMark(m.marks[i])
+	return true
+}
+
+// markReference returns the mark most recently jumped to, if it's still
+// marked and in view, as the line from which to search for the next or
+// previous mark.
+func (m Model) markReference() (int, bool) {
+	if m.lastMark >= m.YOffset && m.lastMark < m.YOffset+m.Height && m.Marked(m.lastMark) {
+		return m.lastMark, true
+	}
+	return 0, false
+}
+
+func (m *Model) gotoMark(line int) {
+	m.lastMark = line
+	m.SetYOffset(line - m.Height/2) //nolint:gomnd
+}
+
 // maxYOffset returns the maximum possible value of the y-offset based on the
 // viewport's content and set height.
 func (m Model) maxYOffset() int {
@@ -121,6 +361,32 @@ func (m Model) visibleLines() (lines []string) {
 		top := max(0, m.YOffset)
 		bottom := clamp(m.YOffset+m.Height, top, len(m.lines))
 		lines = m.lines[top:bottom]
+
+		// Highlight search matches
+		if len(m.matches) > 0 {
+			highlighted := make([]string, len(lines))
+			for i, l := range lines {
+				highlighted[i] = m.highlightLine(top+i, l)
+			}
+			lines = highlighted
+		}
+
+		// Render the mark gutter
+		if len(m.marks) > 0 {
+			var (
+				mark  = m.MarkStyle.Render(m.MarkIndicator)
+				blank = strings.Repeat(" ", lipgloss.Width(mark))
+			)
+			gutter := make([]string, len(lines))
+			for i, l := range lines {
+				if m.Marked(top + i) {
+					gutter[i] = mark + l
+					continue
+				}
+				gutter[i] = blank + l
+			}
+			lines = gutter
+		}
 	}
 	return lines
 }
@@ -138,6 +404,9 @@ func (m Model) scrollArea() (top, bottom int) {
 // SetYOffset sets the Y offset.
 func (m *Model) SetYOffset(n int) {
 	m.YOffset = clamp(n, 0, m.maxYOffset())
+	if m.followEnabled {
+		m.following = m.AtBottom()
+	}
 }
 
 // ViewDown moves the view down by the number of lines in the viewport.
@@ -279,6 +548,13 @@ func (m Model) updateAsModel(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Only keep the spinner ticking while we're loading.
+		if !m.loading {
+			break
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.KeyMap.PageDown):
@@ -350,6 +626,13 @@ func (m Model) View() string {
 		return strings.Repeat("\n", m.Height-1)
 	}
 
+	if m.loading {
+		return m.Style.Copy().
+			UnsetWidth().
+			UnsetHeight().
+			Render(lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center, m.spinner.View()))
+	}
+
 	lines := m.visibleLines()
 
 	// Fill empty space with newlines
@@ -0,0 +1,58 @@
+package viewport
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns n lines of content, numbered from 0.
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestPrevMarkFromTop(t *testing.T) {
+	m := New(80, 10)
+	m.SetContent(numberedLines(100))
+	m.AddMark(5)
+	m.AddMark(19)
+	m.SetYOffset(20)
+
+	// The mark just above the top of the viewport comes first
+	if !m.PrevMark() {
+		t.Fatal("expected a previous mark")
+	}
+	if m.lastMark != 19 {
+		t.Errorf("expected to jump to line 19, got %d", m.lastMark)
+	}
+
+	if !m.PrevMark() || m.lastMark != 5 {
+		t.Errorf("expected to jump to line 5, got %d", m.lastMark)
+	}
+	if m.PrevMark() {
+		t.Error("expected no mark before line 5")
+	}
+}
+
+func TestNextMarkFromTop(t *testing.T) {
+	m := New(80, 10)
+	m.SetContent(numberedLines(100))
+	m.AddMark(20)
+	m.AddMark(40)
+	m.SetYOffset(20)
+
+	// A mark on the top line of the viewport comes first
+	if !m.NextMark() || m.lastMark != 20 {
+		t.Errorf("expected to jump to line 20, got %d", m.lastMark)
+	}
+	if !m.NextMark() || m.lastMark != 40 {
+		t.Errorf("expected to jump to line 40, got %d", m.lastMark)
+	}
+	if m.NextMark() {
+		t.Error("expected no mark after line 40")
+	}
+}